@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"quantumswap-cli/contracts/corev2"
+	"quantumswap-cli/contracts/pairv2"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Fee switch of the v2 factory. When feeTo is set, every pair mints 1/6th of the growth in sqrt(k) to feeTo as LP tokens,
+// the next time liquidity is added or removed. kLast is the reserve product at that last mint or burn.
+
+type v2PairProtocolFee struct {
+	Pair        common.Address
+	Token0      common.Address
+	Token1      common.Address
+	Liquidity   *big.Int // LP tokens that would be minted to feeTo on the next mint or burn
+	Amount0     *big.Int // share of reserve0 those LP tokens represent
+	Amount1     *big.Int
+	FeeToShares *big.Int // LP tokens already held by feeTo
+}
+
+func checkFeeToSetter(factory *corev2.Corev2) error {
+	feeToSetter, err := factory.FeeToSetter(nil)
+	if err != nil {
+		return err
+	}
+	if feeToSetter.IsEqualTo(fromAddress) == false {
+		return fmt.Errorf("FROM_ADDRESS %s is not the feeToSetter %s of the factory %s", fromAddress, feeToSetter, v2CoreFactoryAddress)
+	}
+	return nil
+}
+
+func getV2FeeTo() (common.Address, common.Address, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+
+	contract, err := corev2.NewCorev2(v2CoreFactoryAddress, client)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+
+	feeTo, err := contract.FeeTo(nil)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+
+	feeToSetter, err := contract.FeeToSetter(nil)
+	if err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+
+	return feeTo, feeToSetter, nil
+}
+
+// setV2FeeTo turns the protocol fee on, paid to feeTo, or off when feeTo is the zero address
+func setV2FeeTo(feeTo common.Address) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := corev2.NewCorev2(v2CoreFactoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkFeeToSetter(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.SetFeeTo(txnOpts, feeTo)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to set the v2 feeTo has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+func setV2FeeToSetter(feeToSetter common.Address) (*types.Transaction, error) {
+	if feeToSetter.IsEqualTo(common.Address{}) {
+		return nil, errors.New("new feeToSetter should not be the zero address")
+	}
+
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := corev2.NewCorev2(v2CoreFactoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkFeeToSetter(contract)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.SetFeeToSetter(txnOpts, feeToSetter)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to set the v2 feeToSetter has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// computeV2ProtocolFeeLiquidity returns the LP tokens UniswapV2Pair._mintFee would mint to feeTo for the given reserves
+func computeV2ProtocolFeeLiquidity(reserve0 *big.Int, reserve1 *big.Int, kLast *big.Int, totalSupply *big.Int) *big.Int {
+	if kLast.Sign() == 0 {
+		return big.NewInt(0)
+	}
+
+	rootK := new(big.Int).Sqrt(new(big.Int).Mul(reserve0, reserve1))
+	rootKLast := new(big.Int).Sqrt(kLast)
+	if rootK.Cmp(rootKLast) <= 0 {
+		return big.NewInt(0)
+	}
+
+	numerator := new(big.Int).Mul(totalSupply, new(big.Int).Sub(rootK, rootKLast))
+	denominator := new(big.Int).Add(new(big.Int).Mul(rootK, big.NewInt(5)), rootKLast)
+	return numerator.Div(numerator, denominator)
+}
+
+// getV2ProtocolFees estimates the protocol fee accrued by every pair of the factory since its kLast was recorded.
+// Pairs that have accrued nothing and whose LP tokens are not held by feeTo are left out
+func getV2ProtocolFees() (common.Address, []v2PairProtocolFee, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	blockNumber, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNumber)}
+
+	factory, err := corev2.NewCorev2(v2CoreFactoryAddress, client)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	feeTo, err := factory.FeeTo(opts)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	feeOn := feeTo.IsEqualTo(common.Address{}) == false
+
+	pairCount, err := factory.AllPairsLength(opts)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	fees := make([]v2PairProtocolFee, 0)
+	for i := int64(0); i < pairCount.Int64(); i++ {
+		pairAddress, err := factory.AllPairs(opts, big.NewInt(i))
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+
+		pair, err := pairv2.NewPairv2(pairAddress, client)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+
+		fee := v2PairProtocolFee{
+			Pair:        pairAddress,
+			Liquidity:   big.NewInt(0),
+			Amount0:     big.NewInt(0),
+			Amount1:     big.NewInt(0),
+			FeeToShares: big.NewInt(0),
+		}
+
+		if feeOn {
+			// with the fee switch off nothing is minted, whatever kLast holds
+			kLast, err := pair.KLast(opts)
+			if err != nil {
+				return common.Address{}, nil, err
+			}
+			reserves, err := pair.GetReserves(opts)
+			if err != nil {
+				return common.Address{}, nil, err
+			}
+			totalSupply, err := pair.TotalSupply(opts)
+			if err != nil {
+				return common.Address{}, nil, err
+			}
+
+			fee.Liquidity = computeV2ProtocolFeeLiquidity(reserves.Reserve0, reserves.Reserve1, kLast, totalSupply)
+			if fee.Liquidity.Sign() > 0 {
+				supplyAfterMint := new(big.Int).Add(totalSupply, fee.Liquidity)
+				fee.Amount0 = new(big.Int).Div(new(big.Int).Mul(fee.Liquidity, reserves.Reserve0), supplyAfterMint)
+				fee.Amount1 = new(big.Int).Div(new(big.Int).Mul(fee.Liquidity, reserves.Reserve1), supplyAfterMint)
+			}
+
+			fee.FeeToShares, err = pair.BalanceOf(opts, feeTo)
+			if err != nil {
+				return common.Address{}, nil, err
+			}
+		}
+
+		if fee.Liquidity.Sign() == 0 && fee.FeeToShares.Sign() == 0 {
+			continue
+		}
+
+		fee.Token0, err = pair.Token0(opts)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		fee.Token1, err = pair.Token1(opts)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+
+		fees = append(fees, fee)
+	}
+
+	return feeTo, fees, nil
+}
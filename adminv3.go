@@ -0,0 +1,414 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"quantumswap-cli/contracts/core"
+	"quantumswap-cli/contracts/v3pool"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Owner actions of the v3 factory and pools. Pools are governed by the owner of the factory that deployed them.
+
+// The fee is capped at 100% (1e6 hundredths of a bip) and the tick spacing at 16384 by UniswapV3Factory.enableFeeAmount
+const MAX_FEE_AMOUNT = 1000000
+const MAX_TICK_SPACING = 16384
+
+// Protocol fee denominators accepted by UniswapV3Pool.setFeeProtocol. 0 turns the protocol fee off, otherwise 1/N of the swap fees is taken
+const MIN_FEE_PROTOCOL = 4
+const MAX_FEE_PROTOCOL = 10
+
+func checkFactoryOwner(factory *core.Core, factoryAddress common.Address) error {
+	owner, err := factory.Owner(nil)
+	if err != nil {
+		return err
+	}
+	if owner.IsEqualTo(fromAddress) == false {
+		return fmt.Errorf("FROM_ADDRESS %s is not the owner %s of the factory %s", fromAddress, owner, factoryAddress)
+	}
+	return nil
+}
+
+// checkPoolOwner checks that fromAddress owns the factory of the pool
+func checkPoolOwner(client *ethclient.Client, pool *v3pool.V3pool) error {
+	factoryAddress, err := pool.Factory(nil)
+	if err != nil {
+		return err
+	}
+	factory, err := core.NewCore(factoryAddress, client)
+	if err != nil {
+		return err
+	}
+	return checkFactoryOwner(factory, factoryAddress)
+}
+
+func validateFeeProtocol(feeProtocol uint8) error {
+	if feeProtocol != 0 && (feeProtocol < MIN_FEE_PROTOCOL || feeProtocol > MAX_FEE_PROTOCOL) {
+		return fmt.Errorf("fee protocol %d should be 0 (off) or between %d and %d", feeProtocol, MIN_FEE_PROTOCOL, MAX_FEE_PROTOCOL)
+	}
+	return nil
+}
+
+func getFactoryOwner() (common.Address, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	factory, err := core.NewCore(v3CoreFactoryAddress, client)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	return factory.Owner(nil)
+}
+
+func enableFeeAmount(fee int64, tickSpacing int64) (*types.Transaction, error) {
+	if fee < 0 || fee >= MAX_FEE_AMOUNT {
+		return nil, fmt.Errorf("fee should be at least 0 and less than %d", MAX_FEE_AMOUNT)
+	}
+	if tickSpacing <= 0 || tickSpacing >= MAX_TICK_SPACING {
+		return nil, fmt.Errorf("tick spacing should be greater than 0 and less than %d", MAX_TICK_SPACING)
+	}
+
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := core.NewCore(v3CoreFactoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkFactoryOwner(contract, v3CoreFactoryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	currentTickSpacing, err := contract.FeeAmountTickSpacing(nil, big.NewInt(fee))
+	if err != nil {
+		return nil, err
+	}
+	if currentTickSpacing.Sign() != 0 {
+		return nil, fmt.Errorf("fee tier %d is already enabled with tick spacing %d", fee, currentTickSpacing)
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.EnableFeeAmount(txnOpts, big.NewInt(fee), big.NewInt(tickSpacing))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to enable fee amount has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+func setFactoryOwner(newOwner common.Address) (*types.Transaction, error) {
+	if newOwner.IsEqualTo(common.Address{}) {
+		return nil, errors.New("new owner should not be the zero address")
+	}
+
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := core.NewCore(v3CoreFactoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkFactoryOwner(contract, v3CoreFactoryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.SetOwner(txnOpts, newOwner)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to set the factory owner has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// getFeeProtocol returns the current protocol fee denominators of token0 and token1 of the pool, and the protocol fees accrued so far
+func getFeeProtocol(poolAddress common.Address) (uint8, uint8, *big.Int, *big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	contract, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	slot0, err := contract.Slot0(nil)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	protocolFees, err := contract.ProtocolFees(nil)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	// the pool packs the denominator of token0 in the low 4 bits and the denominator of token1 in the high 4 bits
+	return slot0.FeeProtocol % 16, slot0.FeeProtocol >> 4, protocolFees.Token0, protocolFees.Token1, nil
+}
+
+func setFeeProtocol(poolAddress common.Address, feeProtocol0 uint8, feeProtocol1 uint8) (*types.Transaction, error) {
+	err := validateFeeProtocol(feeProtocol0)
+	if err != nil {
+		return nil, err
+	}
+	err = validateFeeProtocol(feeProtocol1)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPoolOwner(client, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.SetFeeProtocol(txnOpts, feeProtocol0, feeProtocol1)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to set the fee protocol has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// collectProtocol collects all the protocol fees accrued by the pool. The pool keeps 1 wei of each token when everything
+// is collected, so that the storage slot is not cleared
+func collectProtocol(poolAddress common.Address, recipient common.Address) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPoolOwner(client, contract)
+	if err != nil {
+		return nil, err
+	}
+
+	protocolFees, err := contract.ProtocolFees(nil)
+	if err != nil {
+		return nil, err
+	}
+	if protocolFees.Token0.Sign() == 0 && protocolFees.Token1.Sign() == 0 {
+		return nil, errors.New("the pool has not accrued any protocol fees")
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.CollectProtocol(txnOpts, recipient, MAX_UINT128, MAX_UINT128)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to collect protocol fees has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash(), "token0", protocolFees.Token0, "token1", protocolFees.Token1)
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
@@ -192,3 +192,13 @@ func ParseBigFloat(value string) (*big.Float, error) {
 	_, err := fmt.Sscan(value, f)
 	return f, err
 }
+
+// ParseBigRat parse string value to an exact big.Rat
+// Accepts decimal (0.25), fractional (1/4) and scientific (2.5e-1) notation
+func ParseBigRat(value string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, errors.New("invalid number " + value)
+	}
+	return r, nil
+}
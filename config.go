@@ -21,23 +21,25 @@ type DeploymentSettings struct {
 	ContractId   Contract
 	ContractName string
 	Gas          uint64
+	Artifact     string // name of the compiled contract, read from ARTIFACTS_DIR/<Artifact>.abi and .bin when not embedded in a binding
+	AddressEnv   string // environment variable the other commands read the address from
 }
 
 var (
-	v2CoreContract                     = DeploymentSettings{ContractId: V2CoreContract, ContractName: "V2CoreContract", Gas: 6000000}
-	wqContract                         = DeploymentSettings{ContractId: WrappedQContract, ContractName: "WrappedQContract", Gas: 6000000}
-	v3CoreContract                     = DeploymentSettings{ContractId: V3CoreContract, ContractName: "V3CoreContract", Gas: 6000000}
-	multiCallContract                  = DeploymentSettings{ContractId: MultiCallContract, ContractName: "MultiCallContract", Gas: 6000000}
-	proxyAdminContract                 = DeploymentSettings{ContractId: ProxyAdminContract, ContractName: "ProxyAdminContract", Gas: 6000000}
-	tickLensContract                   = DeploymentSettings{ContractId: TickLensContract, ContractName: "TickLensContract", Gas: 6000000}
-	nftDescriptorLibraryContract       = DeploymentSettings{ContractId: NftDescriptorLibraryContract, ContractName: "NftDescriptorLibraryContract", Gas: 6000000}
-	nftPositionDescriptorContract      = DeploymentSettings{ContractId: NftPositionDescriptorContract, ContractName: "NftPositionDescriptorContract", Gas: 6000000}
-	transparentProxyContract           = DeploymentSettings{ContractId: TransparentProxyContract, ContractName: "TransparentProxyContract", Gas: 6000000}
-	nonfungiblePositionManagerContract = DeploymentSettings{ContractId: NonfungiblePositionManagerContract, ContractName: "NonfungiblePositionManagerContract", Gas: 6000000}
-	v3MigratorContract                 = DeploymentSettings{ContractId: V3MigratorContract, ContractName: "V3MigratorContract", Gas: 6000000}
-	v3StakerContract                   = DeploymentSettings{ContractId: V3StakerContract, ContractName: "V3StakerContract", Gas: 6000000}
-	quoterV2Contract                   = DeploymentSettings{ContractId: QuoterV2Contract, ContractName: "QuoterV2Contract", Gas: 6000000}
-	v3SwapRouterContract               = DeploymentSettings{ContractId: V3SwapRouterContract, ContractName: "V3SwapRouterContract", Gas: 6000000}
+	v2CoreContract                     = DeploymentSettings{ContractId: V2CoreContract, ContractName: "V2CoreContract", Gas: 6000000, Artifact: "UniswapV2Factory", AddressEnv: "V2_CORE_FACTORY_CONTRACT_ADDRESS"}
+	wqContract                         = DeploymentSettings{ContractId: WrappedQContract, ContractName: "WrappedQContract", Gas: 6000000, Artifact: "WQ", AddressEnv: "WQ_CONTRACT_ADDRESS"}
+	v3CoreContract                     = DeploymentSettings{ContractId: V3CoreContract, ContractName: "V3CoreContract", Gas: 6000000, Artifact: "UniswapV3Factory", AddressEnv: "V3_CORE_FACTORY_CONTRACT_ADDRESS"}
+	multiCallContract                  = DeploymentSettings{ContractId: MultiCallContract, ContractName: "MultiCallContract", Gas: 6000000, Artifact: "UniswapInterfaceMulticall", AddressEnv: "MULTICALL_CONTRACT_ADDRESS"}
+	proxyAdminContract                 = DeploymentSettings{ContractId: ProxyAdminContract, ContractName: "ProxyAdminContract", Gas: 6000000, Artifact: "ProxyAdmin", AddressEnv: "PROXY_ADMIN_CONTRACT_ADDRESS"}
+	tickLensContract                   = DeploymentSettings{ContractId: TickLensContract, ContractName: "TickLensContract", Gas: 6000000, Artifact: "TickLens", AddressEnv: "TICK_LENS_CONTRACT_ADDRESS"}
+	nftDescriptorLibraryContract       = DeploymentSettings{ContractId: NftDescriptorLibraryContract, ContractName: "NftDescriptorLibraryContract", Gas: 6000000, Artifact: "NFTDescriptor", AddressEnv: "NFT_DESCRIPTOR_LIBRARY_ADDRESS"}
+	nftPositionDescriptorContract      = DeploymentSettings{ContractId: NftPositionDescriptorContract, ContractName: "NftPositionDescriptorContract", Gas: 6000000, Artifact: "NonfungibleTokenPositionDescriptor", AddressEnv: "NFT_POSITION_DESCRIPTOR_CONTRACT_ADDRESS"}
+	transparentProxyContract           = DeploymentSettings{ContractId: TransparentProxyContract, ContractName: "TransparentProxyContract", Gas: 6000000, Artifact: "TransparentUpgradeableProxy", AddressEnv: "TRANSPARENT_PROXY_CONTRACT_ADDRESS"}
+	nonfungiblePositionManagerContract = DeploymentSettings{ContractId: NonfungiblePositionManagerContract, ContractName: "NonfungiblePositionManagerContract", Gas: 6000000, Artifact: "NonfungiblePositionManager", AddressEnv: "NONFUNGIBLE_POSITION_MANAGER_CONTRACT_ADDRESS"}
+	v3MigratorContract                 = DeploymentSettings{ContractId: V3MigratorContract, ContractName: "V3MigratorContract", Gas: 6000000, Artifact: "V3Migrator", AddressEnv: "V3_MIGRATOR_CONTRACT_ADDRESS"}
+	v3StakerContract                   = DeploymentSettings{ContractId: V3StakerContract, ContractName: "V3StakerContract", Gas: 6000000, Artifact: "UniswapV3Staker", AddressEnv: "V3_STAKER_CONTRACT_ADDRESS"}
+	quoterV2Contract                   = DeploymentSettings{ContractId: QuoterV2Contract, ContractName: "QuoterV2Contract", Gas: 6000000, Artifact: "QuoterV2", AddressEnv: "QUOTER_V2_CONTRACT_ADDRESS"}
+	v3SwapRouterContract               = DeploymentSettings{ContractId: V3SwapRouterContract, ContractName: "V3SwapRouterContract", Gas: 6000000, Artifact: "SwapRouter02", AddressEnv: "SWAP_ROUTER_CONTRACT_ADDRESS"}
 
 	ContractMap = map[Contract]DeploymentSettings{
 		WrappedQContract:                   wqContract,
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"quantumswap-cli/contracts/corev2"
+	"quantumswap-cli/contracts/pairv2"
+	"quantumswap-cli/contracts/v3pool"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Pairs and pools are deployed by their factory with CREATE2, so their addresses follow from the factory address, the tokens
+// (and fee) and the hash of the pair or pool creation code, without calling the factory.
+
+func sortTokens(tokenA common.Address, tokenB common.Address) (common.Address, common.Address) {
+	if bytes.Compare(tokenA.Bytes(), tokenB.Bytes()) < 0 {
+		return tokenA, tokenB
+	}
+	return tokenB, tokenA
+}
+
+// getV2PairInitCodeHash returns the hash of the UniswapV2Pair creation code, which is INIT_CODE_HASH of the v2 factory
+func getV2PairInitCodeHash() common.Hash {
+	return crypto.Keccak256Hash(common.FromHex(pairv2.Pairv2MetaData.Bin))
+}
+
+// getV3PoolInitCodeHash returns the hash of the UniswapV3Pool creation code, POOL_INIT_CODE_HASH of the periphery contracts
+func getV3PoolInitCodeHash() common.Hash {
+	return crypto.Keccak256Hash(common.FromHex(v3pool.V3poolMetaData.Bin))
+}
+
+// computeV2PairAddress returns the address UniswapV2Factory.createPair deploys the pair at, with the salt keccak256(abi.encodePacked(token0, token1))
+func computeV2PairAddress(factoryAddress common.Address, tokenA common.Address, tokenB common.Address, initCodeHash common.Hash) (common.Address, error) {
+	if tokenA.IsEqualTo(tokenB) {
+		return common.Address{}, errors.New("identical token addresses")
+	}
+
+	token0, token1 := sortTokens(tokenA, tokenB)
+	salt := crypto.Keccak256Hash(token0.Bytes(), token1.Bytes())
+	return crypto.CreateAddress2(factoryAddress, salt, initCodeHash.Bytes()), nil
+}
+
+// computeV3PoolAddress returns the address UniswapV3PoolDeployer deploys the pool at, with the salt keccak256(abi.encode(token0, token1, fee))
+func computeV3PoolAddress(factoryAddress common.Address, tokenA common.Address, tokenB common.Address, fee int64, initCodeHash common.Hash) (common.Address, error) {
+	if tokenA.IsEqualTo(tokenB) {
+		return common.Address{}, errors.New("identical token addresses")
+	}
+	if fee < 0 || fee >= MAX_FEE_AMOUNT {
+		return common.Address{}, errors.New("invalid fee")
+	}
+
+	token0, token1 := sortTokens(tokenA, tokenB)
+	salt := crypto.Keccak256Hash(
+		common.LeftPadBytes(token0.Bytes(), 32),
+		common.LeftPadBytes(token1.Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(fee).Bytes(), 32),
+	)
+	return crypto.CreateAddress2(factoryAddress, salt, initCodeHash.Bytes()), nil
+}
+
+// getV2FactoryInitCodeHash reads INIT_CODE_HASH of the v2 factory, to cross-check the hash the pair addresses are computed with
+func getV2FactoryInitCodeHash() (common.Hash, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	contract, err := corev2.NewCorev2(v2CoreFactoryAddress, client)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	initCodeHash, err := contract.INITCODEHASH(nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.Hash(initCodeHash), nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"quantumswap-cli/contracts/swaprouter"
+
+	ethereum "github.com/quantumcoinproject/quantum-coin-go"
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Deadlines of the router, position manager and migrator calls. A transaction mined after its deadline reverts, so that a
+// transaction held up in the mempool does not execute hours later at a stale price.
+
+const DEADLINE_FLAG = "--deadline"
+const DEFAULT_DEADLINE = 20 * time.Minute
+
+// deadlineDuration is added to the timestamp of the latest block, it is set with --deadline
+var deadlineDuration = DEFAULT_DEADLINE
+
+// A transaction that is still not mined this long after its deadline is no longer waited for, it can only revert once mined
+const DEADLINE_WAIT_GRACE = time.Minute
+
+// Revert reasons of the deadline checks of UniswapV2Router02 and of the v3 periphery contracts (PeripheryValidation)
+var deadlineRevertReasons = []string{"UniswapV2Router: EXPIRED", "Transaction too old"}
+
+func parseDeadline(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s, it should be a duration such as 90s, 10m or 1h", DEADLINE_FLAG, value)
+	}
+	if duration < time.Second {
+		return 0, fmt.Errorf("%s should be at least 1s", DEADLINE_FLAG)
+	}
+	return duration, nil
+}
+
+// getDeadline returns the timestamp of the latest block plus the --deadline duration
+func getDeadline() (*big.Int, error) {
+	now, err := getLatestBlockTime()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := now + uint64(deadlineDuration/time.Second)
+	fmt.Println("Deadline", deadline, time.Unix(int64(deadline), 0).UTC().Format(time.RFC3339), "("+deadlineDuration.String()+" after the latest block)")
+
+	return new(big.Int).SetUint64(deadline), nil
+}
+
+// checkDeadlineError explains an error that is the revert of an expired deadline, other errors are returned as is
+func checkDeadlineError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, reason := range deadlineRevertReasons {
+		if strings.Contains(err.Error(), reason) {
+			return fmt.Errorf("the deadline passed before the transaction was mined, send it again or use a longer %s: %w", DEADLINE_FLAG, err)
+		}
+	}
+	return err
+}
+
+// getFailedTransactionError replays a failed transaction against the state of the block it was mined in to get its revert reason
+func getFailedTransactionError(client *ethclient.Client, tx *types.Transaction, receipt *types.Receipt) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return errors.New("the transaction failed")
+	}
+
+	msg := ethereum.CallMsg{From: from, To: tx.To(), Gas: tx.Gas(), GasPrice: tx.GasPrice(), Value: tx.Value(), Data: tx.Data()}
+	_, err = client.CallContract(context.Background(), msg, receipt.BlockNumber)
+	if err == nil {
+		return errors.New("the transaction failed")
+	}
+	return checkDeadlineError(fmt.Errorf("the transaction failed: %w", err))
+}
+
+// waitMinedUntilDeadline waits for a transaction to be mined until DEADLINE_WAIT_GRACE after its deadline, a block timestamp.
+// It returns a nil receipt when the transaction is not mined by then: it was dropped or its gas price is too low
+func waitMinedUntilDeadline(client *ethclient.Client, tx *types.Transaction, deadline *big.Int) (*types.Receipt, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Unix(deadline.Int64(), 0).Add(DEADLINE_WAIT_GRACE))
+	defer cancel()
+
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		fmt.Println("The transaction", tx.Hash(), "was not mined by its deadline. It was dropped or its gas price is too low; if it is mined now it reverts.")
+		return nil, nil
+	}
+	return receipt, err
+}
+
+// waitForDeadline waits for a transaction that has a deadline to be mined, so that a revert because the deadline passed
+// (or any other revert) is reported instead of going unnoticed
+func waitForDeadline(client *ethclient.Client, tx *types.Transaction, deadline *big.Int) error {
+	fmt.Println("Waiting for the transaction to be mined...")
+	receipt, err := waitMinedUntilDeadline(client, tx, deadline)
+	if err != nil || receipt == nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return getFailedTransactionError(client, tx, receipt)
+	}
+	fmt.Println("The transaction was mined in block", receipt.BlockNumber)
+	return nil
+}
+
+// sendSwapWithDeadline sends a SwapRouter02 swap through multicall(uint256 deadline, bytes[] data), since the swap
+// params of SwapRouter02 have no deadline. It returns the transaction and its deadline
+func sendSwapWithDeadline(contract *swaprouter.Swaprouter, txnOpts *bind.TransactOpts, method string, swapParams interface{}) (*types.Transaction, *big.Int, error) {
+	parsed, err := swaprouter.SwaprouterMetaData.GetAbi()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	swapData, err := parsed.Pack(method, swapParams)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deadline, err := getDeadline()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := contract.Multicall0(txnOpts, deadline, [][]byte{swapData})
+	if err != nil {
+		return nil, nil, checkDeadlineError(err)
+	}
+	return tx, deadline, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDeadline(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		fails bool
+	}{
+		{"20m", 20 * time.Minute, false},
+		{"90s", 90 * time.Second, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"1s", time.Second, false},
+		{"999ms", 0, true},
+		{"0s", 0, true},
+		{"-5m", 0, true},
+		{"10", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+
+	for _, test := range tests {
+		got, err := parseDeadline(test.value)
+		if test.fails {
+			if err == nil {
+				t.Errorf("%q: expected an error, got %v", test.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.value, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q: got %v, want %v", test.value, got, test.want)
+		}
+	}
+}
+
+func TestCheckDeadlineError(t *testing.T) {
+	if checkDeadlineError(nil) != nil {
+		t.Errorf("nil error: got %v", checkDeadlineError(nil))
+	}
+
+	tests := []struct {
+		err     error
+		expired bool
+	}{
+		{errors.New("execution reverted: UniswapV2Router: EXPIRED"), true},
+		{errors.New("execution reverted: Transaction too old"), true},
+		{errors.New("the transaction failed: execution reverted: Transaction too old"), true},
+		{errors.New("execution reverted: UniswapV2Router: INSUFFICIENT_OUTPUT_AMOUNT"), false},
+		{errors.New("execution reverted: Too little received"), false},
+		{errors.New("insufficient funds for gas * price + value"), false},
+	}
+
+	for _, test := range tests {
+		got := checkDeadlineError(test.err)
+		if errors.Is(got, test.err) == false {
+			t.Errorf("%v: the original error is not wrapped in %v", test.err, got)
+		}
+		expired := strings.Contains(got.Error(), DEADLINE_FLAG)
+		if expired != test.expired {
+			t.Errorf("%v: got %v, expired %v", test.err, got, test.expired)
+		}
+		if test.expired == false && got != test.err {
+			t.Errorf("%v: changed to %v", test.err, got)
+		}
+	}
+}
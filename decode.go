@@ -0,0 +1,425 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"quantumswap-cli/contracts/core"
+	"quantumswap-cli/contracts/corev2"
+	"quantumswap-cli/contracts/nonfungiblepositionmanager"
+	"quantumswap-cli/contracts/pairv2"
+	"quantumswap-cli/contracts/quoterv2"
+	"quantumswap-cli/contracts/swaprouter"
+	"quantumswap-cli/contracts/v2swaprouter"
+	"quantumswap-cli/contracts/v3migrator"
+	"quantumswap-cli/contracts/v3pool"
+	"quantumswap-cli/contracts/v3staker"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi"
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/common/hexutil"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Decoding of calldata against the ABIs of the bindings bundled with quantumswap-cli.
+
+const SELECTOR_LENGTH = 4
+
+// bundledABI is the ABI of a bundled binding, AddressEnv names the variable that configures the address of its contract, if any
+type bundledABI struct {
+	Name       string
+	AddressEnv string
+	ABI        *abi.ABI
+}
+
+var bundledMetaData = []struct {
+	Name       string
+	AddressEnv string
+	MetaData   *bind.MetaData
+}{
+	{"SwapRouter02", v3SwapRouterContract.AddressEnv, swaprouter.SwaprouterMetaData},
+	{"UniswapV2Router02", "SWAP_ROUTER_V2_CONTRACT_ADDRESS", v2swaprouter.V2swaprouterMetaData},
+	{"NonfungiblePositionManager", nonfungiblePositionManagerContract.AddressEnv, nonfungiblepositionmanager.NonfungiblepositionmanagerMetaData},
+	{"UniswapV3Factory", v3CoreContract.AddressEnv, core.CoreMetaData},
+	{"UniswapV2Factory", v2CoreContract.AddressEnv, corev2.Corev2MetaData},
+	{"UniswapV3Pool", "", v3pool.V3poolMetaData},
+	{"UniswapV2Pair", "", pairv2.Pairv2MetaData},
+	{"QuoterV2", quoterV2Contract.AddressEnv, quoterv2.Quoterv2MetaData},
+	{"V3Migrator", v3MigratorContract.AddressEnv, v3migrator.V3migratorMetaData},
+	{"UniswapV3Staker", v3StakerContract.AddressEnv, v3staker.V3stakerMetaData},
+}
+
+func getBundledABIs() ([]bundledABI, error) {
+	abis := make([]bundledABI, 0, len(bundledMetaData)+1)
+	for _, bundled := range bundledMetaData {
+		parsed, err := bundled.MetaData.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		abis = append(abis, bundledABI{Name: bundled.Name, AddressEnv: bundled.AddressEnv, ABI: parsed})
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		return nil, err
+	}
+	abis = append(abis, bundledABI{Name: "ERC20", ABI: &parsed})
+
+	return abis, nil
+}
+
+// orderBundledABIs moves the ABI of the contract configured at the address to first, the others keep the order above
+func orderBundledABIs(abis []bundledABI, to *common.Address) []bundledABI {
+	if to == nil {
+		return abis
+	}
+
+	for i, bundled := range abis {
+		if len(bundled.AddressEnv) == 0 {
+			continue
+		}
+		address, ok := getConfiguredAddress(bundled.AddressEnv)
+		if ok == false || address != *to {
+			continue
+		}
+		ordered := make([]bundledABI, 0, len(abis))
+		ordered = append(ordered, bundled)
+		ordered = append(ordered, abis[:i]...)
+		return append(ordered, abis[i+1:]...)
+	}
+	return abis
+}
+
+// decodeCalldata finds the method of the selector in the bundled ABIs and unpacks its arguments. The ABI of the contract at to
+// is tried first when to is not nil, then the others in the order above; a match whose arguments do not unpack is skipped.
+func decodeCalldata(data []byte, to *common.Address) (string, *abi.Method, []interface{}, error) {
+	if len(data) < SELECTOR_LENGTH {
+		return "", nil, nil, errors.New("calldata is shorter than a selector")
+	}
+
+	abis, err := getBundledABIs()
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var unpackErr error
+	for _, bundled := range orderBundledABIs(abis, to) {
+		method, err := bundled.ABI.MethodById(data[:SELECTOR_LENGTH])
+		if err != nil {
+			continue
+		}
+		args, err := method.Inputs.Unpack(data[SELECTOR_LENGTH:])
+		if err != nil {
+			if unpackErr == nil {
+				unpackErr = fmt.Errorf("%s.%s: %w", bundled.Name, method.Name, err)
+			}
+			continue
+		}
+		return bundled.Name, method, args, nil
+	}
+
+	if unpackErr != nil {
+		return "", nil, nil, unpackErr
+	}
+	return "", nil, nil, fmt.Errorf("unknown selector 0x%x", data[:SELECTOR_LENGTH])
+}
+
+// decodedCall is calldata decoded against the bundled ABIs, with the calls packed in a multicall decoded recursively
+type decodedCall struct {
+	Contract string
+	Method   *abi.Method
+	Args     []interface{}
+	Calls    []*decodedCall
+	Data     []byte
+	Err      error
+}
+
+// decodedArg is an argument of a call, with the fields of struct arguments flattened as name.field
+type decodedArg struct {
+	Name  string
+	Value interface{}
+}
+
+// tokenInfo is the symbol and decimals of a token, OK is false when they could not be read
+type tokenInfo struct {
+	Symbol   string
+	Decimals uint8
+	OK       bool
+}
+
+// tokenMetadata reads and caches the symbol and decimals of the tokens in decoded calls. backend may be nil when not connected to a node.
+type tokenMetadata struct {
+	backend bind.ContractBackend
+	tokens  map[common.Address]tokenInfo
+}
+
+func newTokenMetadata(backend bind.ContractBackend) *tokenMetadata {
+	return &tokenMetadata{backend: backend, tokens: make(map[common.Address]tokenInfo)}
+}
+
+func (t *tokenMetadata) lookup(token common.Address) tokenInfo {
+	info, ok := t.tokens[token]
+	if ok || t.backend == nil {
+		return info
+	}
+
+	symbol, err := getTokenSymbol(token, t.backend)
+	if err == nil {
+		var decimals uint8
+		decimals, err = getTokenDecimals(token, t.backend)
+		info = tokenInfo{Symbol: symbol, Decimals: decimals, OK: err == nil}
+	}
+	t.tokens[token] = info
+	return info
+}
+
+func (t *tokenMetadata) formatAddress(address common.Address) string {
+	info := t.lookup(address)
+	if info.OK == false {
+		return address.Hex()
+	}
+	return address.Hex() + " (" + info.Symbol + ")"
+}
+
+// decodeCall decodes calldata sent to the address to, nil when not known, and, for the multicall overloads (bytes[] data, with
+// a leading deadline or previous block hash), each call it packs. The packed calls are made by the contract on itself.
+func decodeCall(data []byte, to *common.Address) *decodedCall {
+	call := &decodedCall{Data: data}
+	call.Contract, call.Method, call.Args, call.Err = decodeCalldata(data, to)
+	if call.Err != nil || call.Method.RawName != "multicall" {
+		return call
+	}
+
+	for _, arg := range call.Args {
+		packed, ok := arg.([][]byte)
+		if ok == false {
+			continue
+		}
+		for _, inner := range packed {
+			call.Calls = append(call.Calls, decodeCall(inner, to))
+		}
+	}
+	return call
+}
+
+func flattenArgs(inputs abi.Arguments, args []interface{}) []decodedArg {
+	flat := make([]decodedArg, 0, len(args))
+	for i, arg := range args {
+		flat = appendArg(flat, inputs[i].Name, arg)
+	}
+	return flat
+}
+
+func appendArg(flat []decodedArg, name string, value interface{}) []decodedArg {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Struct {
+		return append(flat, decodedArg{Name: name, Value: value})
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i).Name
+		field = strings.ToLower(field[:1]) + field[1:]
+		if len(name) > 0 {
+			field = name + "." + field
+		}
+		flat = appendArg(flat, field, v.Field(i).Interface())
+	}
+	return flat
+}
+
+func argBaseName(name string) string {
+	return strings.ToLower(name[strings.LastIndex(name, ".")+1:])
+}
+
+// getCallTokens maps the roles of the tokens of a call (in, out, 0, 1, a, b, or "" for a single token) to their addresses,
+// from the token arguments and the first and last token of a swap path
+func getCallTokens(call *decodedCall, args []decodedArg) map[string]common.Address {
+	roles := map[string]string{"tokenin": "in", "tokenout": "out", "token0": "0", "token1": "1", "tokena": "a", "tokenb": "b",
+		"token": "", "rewardtoken": ""}
+
+	tokens := make(map[string]common.Address)
+	for _, arg := range args {
+		base := argBaseName(arg.Name)
+		if role, ok := roles[base]; ok {
+			if address, ok := arg.Value.(common.Address); ok {
+				tokens[role] = address
+			}
+			continue
+		}
+		if base != "path" {
+			continue
+		}
+
+		var path []common.Address
+		reverse := false
+		switch value := arg.Value.(type) {
+		case []common.Address:
+			path = value
+		case []byte:
+			path, _, _ = decodePath(value)
+			reverse = strings.HasPrefix(call.Method.RawName, "exactOutput")
+		}
+		if len(path) == 0 {
+			continue
+		}
+		tokens["in"], tokens["out"] = path[0], path[len(path)-1]
+		if reverse {
+			tokens["in"], tokens["out"] = tokens["out"], tokens["in"]
+		}
+	}
+	return tokens
+}
+
+// amountRole returns the role of the token of an amount argument, such as in for amountIn and amountInMaximum, a for amountAMin
+// or eth for amountETHMin, an amount of the native currency
+func amountRole(name string) (string, bool) {
+	base := argBaseName(name)
+	if strings.HasPrefix(base, "amount") == false {
+		return "", false
+	}
+
+	rest := strings.TrimPrefix(base, "amount")
+	switch {
+	case strings.HasPrefix(rest, "in"):
+		return "in", true
+	case strings.HasPrefix(rest, "out"):
+		return "out", true
+	case strings.HasPrefix(rest, "eth"):
+		return "eth", true
+	case len(rest) > 0 && strings.ContainsRune("01ab", rune(rest[0])):
+		return rest[:1], true
+	}
+	return "", true
+}
+
+func (t *tokenMetadata) formatArg(call *decodedCall, tokens map[string]common.Address, arg decodedArg) string {
+	switch value := arg.Value.(type) {
+	case common.Address:
+		return t.formatAddress(value)
+	case []common.Address:
+		formatted := make([]string, len(value))
+		for i, address := range value {
+			formatted[i] = t.formatAddress(address)
+		}
+		return "[" + strings.Join(formatted, ", ") + "]"
+	case []byte:
+		if argBaseName(arg.Name) == "path" {
+			pathTokens, fees, err := decodePath(value)
+			if err == nil {
+				route := make([]string, 0, len(pathTokens)+len(fees))
+				for i, token := range pathTokens {
+					route = append(route, t.formatAddress(token))
+					if i < len(fees) {
+						route = append(route, strconv.FormatInt(fees[i], 10))
+					}
+				}
+				return strings.Join(route, " : ")
+			}
+		}
+		return hexutil.Encode(value)
+	case [32]byte:
+		return hexutil.Encode(value[:])
+	case *big.Int:
+		role, isAmount := amountRole(arg.Name)
+		if isAmount == false {
+			return value.String()
+		}
+		if role == "eth" {
+			return value.String() + " (" + formatTokenAmount(value, 18) + " " + NATIVE_CURRENCY_LABEL + ")"
+		}
+		token, ok := tokens[role]
+		if ok == false {
+			return value.String()
+		}
+		info := t.lookup(token)
+		if info.OK == false {
+			return value.String()
+		}
+		return value.String() + " (" + formatTokenAmount(value, info.Decimals) + " " + info.Symbol + ")"
+	}
+	return fmt.Sprintf("%+v", arg.Value)
+}
+
+func (t *tokenMetadata) printCall(call *decodedCall, indent string) {
+	if call.Err != nil {
+		fmt.Println(indent+"could not decode", hexutil.Encode(call.Data), call.Err)
+		return
+	}
+
+	fmt.Println(indent + call.Contract + "." + call.Method.Sig)
+	args := flattenArgs(call.Method.Inputs, call.Args)
+	tokens := getCallTokens(call, args)
+	for _, arg := range args {
+		if _, ok := arg.Value.([][]byte); ok && len(call.Calls) > 0 {
+			fmt.Printf("%s      %s: %d calls\n", indent, arg.Name, len(call.Calls))
+			continue
+		}
+		fmt.Printf("%s      %s: %s\n", indent, arg.Name, t.formatArg(call, tokens, arg))
+	}
+	for i, inner := range call.Calls {
+		fmt.Printf("%s      [%d]\n", indent, i)
+		t.printCall(inner, indent+"      ")
+	}
+}
+
+// printDecodedCalldata prints the method and the arguments of calldata, one argument per line, with the calls of a multicall
+// decoded below it. Token symbols and amounts are shown when backend is not nil. to is the target of the calldata, nil when not known.
+func printDecodedCalldata(data []byte, to *common.Address, backend bind.ContractBackend) error {
+	call := decodeCall(data, to)
+	if call.Err != nil {
+		return call.Err
+	}
+
+	newTokenMetadata(backend).printCall(call, "")
+	return nil
+}
+
+// decodeInput prints decoded calldata sent to the address to, nil when not known, with token symbols and amounts when the
+// node at DP_RAW_URL is reachable
+func decodeInput(data []byte, to *common.Address) error {
+	var backend bind.ContractBackend
+	client, err := ethclient.Dial(rawURL)
+	if err == nil {
+		backend = client
+	}
+	return printDecodedCalldata(data, to, backend)
+}
+
+// decodeTransaction prints the sender, target and value of a transaction and its decoded input
+func decodeTransaction(txHash common.Hash) error {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return err
+	}
+
+	tx, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return err
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("from", from.Hex())
+	if tx.To() == nil {
+		fmt.Println("target", "(contract deployment)")
+	} else {
+		fmt.Println("target", tx.To().Hex())
+	}
+	fmt.Println("value", formatWei(tx.Value()), "pending", isPending)
+	fmt.Println()
+
+	if tx.To() == nil {
+		return nil
+	}
+	return printDecodedCalldata(tx.Data(), tx.To(), client)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+)
+
+func TestAmountRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		isAmount bool
+	}{
+		{"amountIn", "in", true},
+		{"params.amountInMaximum", "in", true},
+		{"amountOutMinimum", "out", true},
+		{"amount0Desired", "0", true},
+		{"amountBMin", "b", true},
+		{"amountETHMin", "eth", true},
+		{"amountETHDesired", "eth", true},
+		{"amount", "", true},
+		{"deadline", "", false},
+	}
+
+	for _, test := range tests {
+		role, isAmount := amountRole(test.name)
+		if role != test.role || isAmount != test.isAmount {
+			t.Errorf("%s: role %q %v, want %q %v", test.name, role, isAmount, test.role, test.isAmount)
+		}
+	}
+}
+
+func TestOrderBundledABIs(t *testing.T) {
+	router := common.HexToAddress("0x" + strings.Repeat("01", common.AddressLength))
+	other := common.HexToAddress("0x" + strings.Repeat("02", common.AddressLength))
+	abis := []bundledABI{
+		{Name: "SwapRouter02", AddressEnv: v3SwapRouterContract.AddressEnv},
+		{Name: "UniswapV3Pool"},
+		{Name: "V3Migrator", AddressEnv: v3MigratorContract.AddressEnv},
+	}
+
+	os.Setenv(v3MigratorContract.AddressEnv, router.Hex())
+	defer os.Unsetenv(v3MigratorContract.AddressEnv)
+
+	tests := []struct {
+		name string
+		to   *common.Address
+		want []string
+	}{
+		{"target not known", nil, []string{"SwapRouter02", "UniswapV3Pool", "V3Migrator"}},
+		{"target is a configured contract", &router, []string{"V3Migrator", "SwapRouter02", "UniswapV3Pool"}},
+		{"target is not configured", &other, []string{"SwapRouter02", "UniswapV3Pool", "V3Migrator"}},
+	}
+
+	for _, test := range tests {
+		ordered := orderBundledABIs(abis, test.to)
+		names := make([]string, len(ordered))
+		for i, bundled := range ordered {
+			names[i] = bundled.Name
+		}
+		if strings.Join(names, ",") != strings.Join(test.want, ",") {
+			t.Errorf("%s: order %v, want %v", test.name, names, test.want)
+		}
+	}
+}
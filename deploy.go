@@ -0,0 +1,497 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"quantumswap-cli/contracts/core"
+	"quantumswap-cli/contracts/corev2"
+	"quantumswap-cli/contracts/nonfungiblepositionmanager"
+	"quantumswap-cli/contracts/swaprouter"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi"
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto/signaturealgorithm"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Deployment of the whole QuantumSwap stack. Contracts are deployed in the order of their Contract id in ContractMap,
+// which is their dependency order. The state file records every deployment as soon as it is sent, so that a deployment
+// that stopped part way is resumed instead of started over.
+
+const DEFAULT_DEPLOYMENT_STATE_FILE = "deployment-state.json"
+const DEFAULT_ADDRESS_BOOK_FILE = "addressbook.env"
+
+// The address book is loaded from this file, when set, by every command
+const ADDRESS_BOOK_ENV = "DP_ADDRESS_BOOK"
+
+// Fully qualified name of the library linked into NonfungibleTokenPositionDescriptor
+const NFT_DESCRIPTOR_LIBRARY_NAME = "contracts/libraries/NFTDescriptor.sol:NFTDescriptor"
+
+// Contracts whose bytecode is embedded in the bindings of this repository; the others are read from ARTIFACTS_DIR
+var embeddedContracts = map[Contract]*bind.MetaData{
+	V3CoreContract:                     core.CoreMetaData,
+	NonfungiblePositionManagerContract: nonfungiblepositionmanager.NonfungiblepositionmanagerMetaData,
+	V2CoreContract:                     corev2.Corev2MetaData,
+	V3SwapRouterContract:               swaprouter.SwaprouterMetaData,
+}
+
+type deployedContract struct {
+	Address common.Address `json:"address"`
+	TxHash  common.Hash    `json:"txHash"`
+}
+
+type deploymentState struct {
+	ChainId   int64                       `json:"chainId"`
+	Deployer  common.Address              `json:"deployer"`
+	Contracts map[string]deployedContract `json:"contracts"`
+}
+
+// getDeploymentOrder returns the contracts of ContractMap sorted by Contract id
+func getDeploymentOrder() []DeploymentSettings {
+	order := make([]DeploymentSettings, 0, len(ContractMap))
+	for id := 0; id < len(ContractMap); id++ {
+		settings, ok := ContractMap[Contract(id)]
+		if ok == false {
+			continue
+		}
+		order = append(order, settings)
+	}
+	return order
+}
+
+func readDeploymentState(stateFile string, chainId int64) (*deploymentState, error) {
+	state := &deploymentState{ChainId: chainId, Deployer: fromAddress, Contracts: make(map[string]deployedContract)}
+
+	data, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the state file %s: %w", stateFile, err)
+	}
+	if state.ChainId != chainId {
+		return nil, fmt.Errorf("the state file %s is for chain id %d, not %d", stateFile, state.ChainId, chainId)
+	}
+	if state.Deployer.IsEqualTo(fromAddress) == false {
+		return nil, fmt.Errorf("the state file %s was created by %s, not FROM_ADDRESS %s", stateFile, state.Deployer, fromAddress)
+	}
+	if state.Contracts == nil {
+		state.Contracts = make(map[string]deployedContract)
+	}
+
+	return state, nil
+}
+
+func writeDeploymentState(stateFile string, state *deploymentState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile, data, 0644)
+}
+
+// loadContractArtifact returns the abi and the creation bytecode of a contract, from its binding or from ARTIFACTS_DIR
+func loadContractArtifact(settings DeploymentSettings, artifactsDir string) (*abi.ABI, string, error) {
+	metaData, ok := embeddedContracts[settings.ContractId]
+	if ok {
+		parsed, err := metaData.GetAbi()
+		if err != nil {
+			return nil, "", err
+		}
+		return parsed, strings.TrimPrefix(metaData.Bin, "0x"), nil
+	}
+
+	abiFile := filepath.Join(artifactsDir, settings.Artifact+".abi")
+	abiData, err := ioutil.ReadFile(abiFile)
+	if err != nil {
+		return nil, "", err
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(abiData)))
+	if err != nil {
+		return nil, "", fmt.Errorf("could not parse %s: %w", abiFile, err)
+	}
+
+	binFile := filepath.Join(artifactsDir, settings.Artifact+".bin")
+	binData, err := ioutil.ReadFile(binFile)
+	if err != nil {
+		return nil, "", err
+	}
+	bin := strings.TrimPrefix(strings.TrimSpace(string(binData)), "0x")
+	if len(bin) == 0 {
+		return nil, "", fmt.Errorf("%s is empty", binFile)
+	}
+
+	return &parsed, bin, nil
+}
+
+// solcReference is a range of bytes of a bytecode, from linkReferences or immutableReferences
+type solcReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+type solcBytecode struct {
+	Object              string                                `json:"object"`
+	LinkReferences      map[string]map[string][]solcReference `json:"linkReferences"`      // source file to library name to placeholders
+	ImmutableReferences map[string][]solcReference            `json:"immutableReferences"` // ast id to the bytes filled by the constructor
+}
+
+// solcContract is the solc standard JSON output of a contract, ARTIFACTS_DIR/<Artifact>.json
+type solcContract struct {
+	Evm struct {
+		Bytecode         solcBytecode `json:"bytecode"`
+		DeployedBytecode solcBytecode `json:"deployedBytecode"`
+	} `json:"evm"`
+}
+
+func loadSolcContract(artifactsDir string, artifact string) (*solcContract, error) {
+	jsonFile := filepath.Join(artifactsDir, artifact+".json")
+	data, err := ioutil.ReadFile(jsonFile)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &solcContract{}
+	err = json.Unmarshal(data, contract)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", jsonFile, err)
+	}
+	return contract, nil
+}
+
+// fillBytecode writes value over the bytes of the hex bytecode bin at reference
+func fillBytecode(bin string, reference solcReference, value []byte) (string, error) {
+	if len(value) != reference.Length || reference.Start < 0 || 2*(reference.Start+reference.Length) > len(bin) {
+		return "", fmt.Errorf("invalid reference of %d bytes at %d for %d bytes", reference.Length, reference.Start, len(value))
+	}
+	return bin[:2*reference.Start] + hex.EncodeToString(value) + bin[2*(reference.Start+reference.Length):], nil
+}
+
+// linkLibraryReferences writes the library address over its placeholders in the hex bytecode bin, at the linkReferences of the
+// solc JSON output. libraryName is the fully qualified name, source file:library.
+func linkLibraryReferences(bin string, linkReferences map[string]map[string][]solcReference, libraryName string, libraryAddress common.Address) (string, error) {
+	for sourceFile, libraries := range linkReferences {
+		for name, references := range libraries {
+			if sourceFile+":"+name != libraryName {
+				continue
+			}
+			for _, reference := range references {
+				var err error
+				bin, err = fillBytecode(bin, reference, libraryAddress.Bytes())
+				if err != nil {
+					return "", fmt.Errorf("library %s: %w", libraryName, err)
+				}
+			}
+		}
+	}
+	return bin, nil
+}
+
+// linkLibrary replaces the solc placeholder of a library in a .bin file, which has no linkReferences. The placeholder spans the
+// address, 2*AddressLength hex characters: __$ followed by the start of the keccak256 hash of the fully qualified name and $__.
+func linkLibrary(bin string, libraryName string, libraryAddress common.Address) string {
+	hash := hex.EncodeToString(crypto.Keccak256([]byte(libraryName)))
+	placeholder := "__$" + hash[:2*common.AddressLength-6] + "$__"
+	return strings.ReplaceAll(bin, placeholder, hex.EncodeToString(libraryAddress.Bytes()))
+}
+
+// linkNftDescriptorLibrary links the NFTDescriptor library into the creation bytecode of NonfungibleTokenPositionDescriptor.
+// The bytecode and the placeholders come from ARTIFACTS_DIR/<Artifact>.json when it exists, otherwise from the .bin.
+func linkNftDescriptorLibrary(settings DeploymentSettings, artifactsDir string, bin string, libraryAddress common.Address) (string, error) {
+	contract, err := loadSolcContract(artifactsDir, settings.Artifact)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return linkLibrary(bin, NFT_DESCRIPTOR_LIBRARY_NAME, libraryAddress), nil
+		}
+		return "", err
+	}
+
+	bytecode := contract.Evm.Bytecode
+	return linkLibraryReferences(strings.TrimPrefix(bytecode.Object, "0x"), bytecode.LinkReferences, NFT_DESCRIPTOR_LIBRARY_NAME, libraryAddress)
+}
+
+// getConstructorArgs returns the constructor arguments of a contract, from the addresses deployed before it
+func getConstructorArgs(id Contract, addresses map[Contract]common.Address) ([]interface{}, error) {
+	switch id {
+	case WrappedQContract, V3CoreContract, MultiCallContract, ProxyAdminContract, TickLensContract, NftDescriptorLibraryContract:
+		return []interface{}{}, nil
+	case NftPositionDescriptorContract:
+		return []interface{}{addresses[WrappedQContract], NATIVE_CURRENCY_LABEL_BYTES}, nil
+	case TransparentProxyContract:
+		// the proxy holds the state of the position descriptor, administered by ProxyAdmin
+		return []interface{}{addresses[NftPositionDescriptorContract], addresses[ProxyAdminContract], []byte{}}, nil
+	case NonfungiblePositionManagerContract:
+		return []interface{}{addresses[V3CoreContract], addresses[WrappedQContract], addresses[TransparentProxyContract]}, nil
+	case V3MigratorContract:
+		return []interface{}{addresses[V3CoreContract], addresses[WrappedQContract], addresses[NonfungiblePositionManagerContract]}, nil
+	case V3StakerContract:
+		return []interface{}{addresses[V3CoreContract], addresses[NonfungiblePositionManagerContract], big.NewInt(MAX_INCENTIVE_START_LEAD_TIME), big.NewInt(MAX_INCENTIVE_DURATION)}, nil
+	case QuoterV2Contract:
+		return []interface{}{addresses[V3CoreContract], addresses[WrappedQContract]}, nil
+	case V2CoreContract:
+		// FROM_ADDRESS is the feeToSetter of the v2 factory
+		return []interface{}{fromAddress}, nil
+	case V3SwapRouterContract:
+		return []interface{}{addresses[V2CoreContract], addresses[V3CoreContract], addresses[NonfungiblePositionManagerContract], addresses[WrappedQContract]}, nil
+	}
+	return nil, fmt.Errorf("unknown contract %d", id)
+}
+
+// checkDeployedContract tells whether a contract recorded in the state file is deployed. A deployment that is still pending
+// is waited for; one that failed or was dropped has to be sent again
+func checkDeployedContract(client *ethclient.Client, deployed deployedContract) (bool, error) {
+	code, err := client.CodeAt(context.Background(), deployed.Address, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) > 0 {
+		return true, nil
+	}
+
+	tx, isPending, err := client.TransactionByHash(context.Background(), deployed.TxHash)
+	if err != nil || isPending == false {
+		return false, nil
+	}
+
+	fmt.Println("Waiting for the pending deployment", deployed.TxHash)
+	_, err = bind.WaitDeployed(context.Background(), client, tx)
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// deployStack deploys the contracts of ContractMap that are not deployed yet according to the state file
+func deployStack(artifactsDir string, stateFile string) (map[Contract]common.Address, error) {
+	if calldataOnly {
+		return nil, fmt.Errorf("deploy sends a transaction per contract, each needing the addresses of the previous ones, and cannot be used with %s", CALLDATA_FLAG)
+	}
+
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := readDeploymentState(stateFile, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make(map[Contract]common.Address)
+	for _, settings := range getDeploymentOrder() {
+		deployed, ok := state.Contracts[settings.ContractName]
+		if ok {
+			isDeployed, err := checkDeployedContract(client, deployed)
+			if err != nil {
+				return nil, err
+			}
+			if isDeployed {
+				fmt.Println(settings.ContractName, "already deployed at", deployed.Address)
+				addresses[settings.ContractId] = deployed.Address
+				continue
+			}
+			fmt.Println(settings.ContractName, "was not deployed by", deployed.TxHash, ", deploying again")
+		}
+
+		parsed, bin, err := loadContractArtifact(settings, artifactsDir)
+		if err != nil {
+			return nil, err
+		}
+		if settings.ContractId == NftPositionDescriptorContract {
+			bin, err = linkNftDescriptorLibrary(settings, artifactsDir, bin, addresses[NftDescriptorLibraryContract])
+			if err != nil {
+				return nil, err
+			}
+		}
+		if strings.Contains(bin, "__") {
+			return nil, fmt.Errorf("%s has unlinked libraries", settings.Artifact)
+		}
+		bytecode, err := hex.DecodeString(bin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bytecode of %s: %w", settings.Artifact, err)
+		}
+
+		args, err := getConstructorArgs(settings.ContractId, addresses)
+		if err != nil {
+			return nil, err
+		}
+
+		nonce, err := getNonce(client, fromAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+		if err != nil {
+			releaseNonce(fromAddress, nonce)
+			return nil, err
+		}
+
+		txnOpts.From = fromAddress
+		txnOpts.Nonce = big.NewInt(int64(nonce))
+		txnOpts.GasLimit = settings.Gas
+		txnOpts.Value = big.NewInt(0)
+
+		address, tx, _, err := bind.DeployContract(txnOpts, *parsed, bytecode, client, args...)
+		if err != nil {
+			releaseNonce(fromAddress, nonce)
+			return nil, fmt.Errorf("could not deploy %s: %w", settings.ContractName, err)
+		}
+
+		state.Contracts[settings.ContractName] = deployedContract{Address: address, TxHash: tx.Hash()}
+		err = writeDeploymentState(stateFile, state)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Println("Deploying", settings.ContractName, "at", address, "transaction hash", tx.Hash())
+		_, err = bind.WaitDeployed(context.Background(), client, tx)
+		if err != nil {
+			return nil, fmt.Errorf("deployment of %s failed, run deploy again to resume: %w", settings.ContractName, err)
+		}
+
+		addresses[settings.ContractId] = address
+		time.Sleep(1000 * time.Millisecond)
+	}
+
+	v3CoreFactoryAddress = addresses[V3CoreContract]
+	err = enableOneBpFeeTier(client, key, chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+// enableOneBpFeeTier enables the 0.01% fee tier, which UniswapV3Factory does not enable in its constructor
+func enableOneBpFeeTier(client *ethclient.Client, key *signaturealgorithm.PrivateKey, chainId int64) error {
+	factory, err := core.NewCore(v3CoreFactoryAddress, client)
+	if err != nil {
+		return err
+	}
+
+	tickSpacing, err := factory.FeeAmountTickSpacing(nil, big.NewInt(ONE_BP_FEE))
+	if err != nil {
+		return err
+	}
+	if tickSpacing.Sign() != 0 {
+		return nil
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return err
+	}
+
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	tx, err := factory.EnableFeeAmount(txnOpts, big.NewInt(ONE_BP_FEE), big.NewInt(ONE_BP_TICK_SPACING))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return err
+	}
+
+	fmt.Println("Enabling the 0.01% fee tier, transaction hash", tx.Hash())
+	receipt, err := bind.WaitMined(context.Background(), client, tx)
+	if err != nil {
+		return err
+	}
+	if receipt.Status == 0 {
+		return errors.New("enabling the 0.01% fee tier failed, run deploy again to retry")
+	}
+	return nil
+}
+
+// writeAddressBook writes the deployed addresses as NAME=ADDRESS lines, with the environment variable names the other commands read
+func writeAddressBook(addressBookFile string, addresses map[Contract]common.Address) error {
+	var builder strings.Builder
+	for _, settings := range getDeploymentOrder() {
+		address, ok := addresses[settings.ContractId]
+		if ok == false {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s=%s\n", settings.AddressEnv, address.Hex()))
+	}
+	return ioutil.WriteFile(addressBookFile, []byte(builder.String()), 0644)
+}
+
+// loadAddressBook sets the environment variables of an address book. Variables that are already set are left as they are
+func loadAddressBook(addressBookFile string) error {
+	file, err := os.Open(addressBookFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		nameAndValue := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+		if len(nameAndValue) != 2 {
+			return fmt.Errorf("invalid address book line %s", line)
+		}
+		name := strings.TrimSpace(nameAndValue[0])
+		if len(os.Getenv(name)) > 0 {
+			continue
+		}
+		err = os.Setenv(name, strings.TrimSpace(nameAndValue[1]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
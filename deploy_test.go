@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto"
+)
+
+func TestLinkLibraryReferences(t *testing.T) {
+	libraryAddress := common.HexToAddress("0x" + strings.Repeat("ab", common.AddressLength))
+	addressHex := hex.EncodeToString(libraryAddress.Bytes())
+	placeholder := "__$" + strings.Repeat("1", 2*common.AddressLength-6) + "$__"
+	other := "__$" + strings.Repeat("2", 2*common.AddressLength-6) + "$__"
+
+	// 0x73 PUSH32 of the library twice, then of another library
+	bin := "73" + placeholder + "73" + placeholder + "73" + other
+	linkReferences := map[string]map[string][]solcReference{
+		"contracts/libraries/NFTDescriptor.sol": {"NFTDescriptor": {
+			{Start: 1, Length: common.AddressLength},
+			{Start: 2 + common.AddressLength, Length: common.AddressLength},
+		}},
+		"contracts/libraries/Other.sol": {"Other": {{Start: 3 + 2*common.AddressLength, Length: common.AddressLength}}},
+	}
+
+	linked, err := linkLibraryReferences(bin, linkReferences, NFT_DESCRIPTOR_LIBRARY_NAME, libraryAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "73" + addressHex + "73" + addressHex + "73" + other
+	if linked != want {
+		t.Errorf("linked %s, want %s", linked, want)
+	}
+
+	// a reference past the end of the bytecode
+	linkReferences["contracts/libraries/NFTDescriptor.sol"]["NFTDescriptor"][1].Start = 3 * common.AddressLength
+	_, err = linkLibraryReferences(bin, linkReferences, NFT_DESCRIPTOR_LIBRARY_NAME, libraryAddress)
+	if err == nil {
+		t.Error("linked a reference past the end of the bytecode")
+	}
+}
+
+func TestLinkLibrary(t *testing.T) {
+	libraryAddress := common.HexToAddress("0x" + strings.Repeat("ab", common.AddressLength))
+	addressHex := hex.EncodeToString(libraryAddress.Bytes())
+
+	// solc writes __$, the first 2*AddressLength-6 hex characters of the hash of the fully qualified name and $__
+	hash := hex.EncodeToString(crypto.Keccak256([]byte(NFT_DESCRIPTOR_LIBRARY_NAME)))
+	placeholder := "__$" + hash[:2*common.AddressLength-6] + "$__"
+	if len(placeholder) != len(addressHex) {
+		t.Fatalf("placeholder of %d characters for an address of %d", len(placeholder), len(addressHex))
+	}
+
+	linked := linkLibrary("73"+placeholder+"5b", NFT_DESCRIPTOR_LIBRARY_NAME, libraryAddress)
+	if linked != "73"+addressHex+"5b" {
+		t.Errorf("linked %s", linked)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi"
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+)
+
+// Minimal ERC20 ABI for reading token metadata and balances. The token contracts are not deployed by QuantumSwap, so no generated binding is bundled for them.
+const erc20ABI = `[
+	{"inputs":[],"name":"name","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"symbol","outputs":[{"internalType":"string","name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"internalType":"address","name":"account","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+func newERC20(tokenAddress common.Address, backend bind.ContractBackend) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(erc20ABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(tokenAddress, parsed, backend, backend, backend), nil
+}
+
+func getTokenDecimals(tokenAddress common.Address, backend bind.ContractBackend) (uint8, error) {
+	contract, err := newERC20(tokenAddress, backend)
+	if err != nil {
+		return 0, err
+	}
+
+	var out []interface{}
+	err = contract.Call(nil, &out, "decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	return *abi.ConvertType(out[0], new(uint8)).(*uint8), nil
+}
+
+func getTokenSymbol(tokenAddress common.Address, backend bind.ContractBackend) (string, error) {
+	contract, err := newERC20(tokenAddress, backend)
+	if err != nil {
+		return "", err
+	}
+
+	var out []interface{}
+	err = contract.Call(nil, &out, "symbol")
+	if err != nil {
+		return "", err
+	}
+
+	return *abi.ConvertType(out[0], new(string)).(*string), nil
+}
+
+func getTokenBalance(tokenAddress common.Address, account common.Address, backend bind.ContractBackend) (*big.Int, error) {
+	contract, err := newERC20(tokenAddress, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []interface{}
+	err = contract.Call(nil, &out, "balanceOf", account)
+	if err != nil {
+		return nil, err
+	}
+
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+// formatTokenAmount formats an amount in the smallest unit of a token as a decimal string with the token's decimals
+func formatTokenAmount(amount *big.Int, decimals uint8) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return new(big.Rat).SetFrac(amount, unit).FloatString(int(decimals))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Options that apply to every command. They can be given anywhere on the command line and are removed from os.Args
+// before the command is dispatched, so that the positional arguments of the commands are unchanged.
+
+const UNSIGNED_FLAG = "--unsigned"
+const CALLDATA_FLAG = "--calldata"
+
+// parseGlobalFlags removes the global options from os.Args and sets them
+func parseGlobalFlags() error {
+	args := make([]string, 0, len(os.Args))
+	for i := 0; i < len(os.Args); i++ {
+		switch os.Args[i] {
+		case UNSIGNED_FLAG:
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("%s needs a FILE", UNSIGNED_FLAG)
+			}
+			unsignedTxFile = os.Args[i+1]
+			i++
+		case CALLDATA_FLAG:
+			calldataOnly = true
+		case DEADLINE_FLAG:
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("%s needs a DURATION", DEADLINE_FLAG)
+			}
+			duration, err := parseDeadline(os.Args[i+1])
+			if err != nil {
+				return err
+			}
+			deadlineDuration = duration
+			i++
+		case SLIPPAGE_FLAG:
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("%s needs a PERCENT", SLIPPAGE_FLAG)
+			}
+			percent, err := parseSlippage(os.Args[i+1])
+			if err != nil {
+				return err
+			}
+			slippageTolerance = percent
+			i++
+		default:
+			args = append(args, os.Args[i])
+		}
+	}
+	os.Args = args
+
+	if calldataOnly && len(unsignedTxFile) > 0 {
+		return fmt.Errorf("%s and %s cannot be used together", CALLDATA_FLAG, UNSIGNED_FLAG)
+	}
+	return nil
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"quantumswap-cli/contracts/pairv2"
+	"quantumswap-cli/contracts/v3pool"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi"
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/common/hexutil"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Flash borrowing from v3 pools (UniswapV3Pool.flash) and v2 pairs (flash swaps through UniswapV2Pair.swap with callback data).
+// A v3 pool calls uniswapV3FlashCallback on msg.sender and charges the pool fee on each amount borrowed.
+// A v2 pair calls uniswapV2Call on the recipient and requires the k invariant to hold with the 0.3% fee on the amounts repaid.
+
+// Swap fee of a v2 pair, 3/1000
+const V2_FEE_NUMERATOR = 997
+const V2_FEE_DENOMINATOR = 1000
+
+type flashFees struct {
+	IsV3       bool
+	Token0     common.Address
+	Token1     common.Address
+	Fee        *big.Int // fee tier of the v3 pool, in hundredths of a bip
+	Fee0       *big.Int
+	Fee1       *big.Int
+	Available0 *big.Int // amount of each token the pool or pair can lend
+	Available1 *big.Int
+}
+
+// isV3Pool tells a v3 pool from a v2 pair; only v3 pools have a fee tier
+func isV3Pool(client *ethclient.Client, poolAddress common.Address) (bool, error) {
+	pool, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return false, err
+	}
+	_, err = pool.Fee(nil)
+	if err == nil {
+		return true, nil
+	}
+
+	pair, err := pairv2.NewPairv2(poolAddress, client)
+	if err != nil {
+		return false, err
+	}
+	_, err = pair.GetReserves(nil)
+	if err != nil {
+		return false, fmt.Errorf("%s is neither a v3 pool nor a v2 pair", poolAddress)
+	}
+	return false, nil
+}
+
+// getV2FlashRepayment returns the smallest amount to pay back, in the borrowed token, for a flash swap of amountOut.
+// UniswapV2Pair.swap requires amountIn * 997 >= amountOut * 1000 when the same token is repaid
+func getV2FlashRepayment(amountOut *big.Int) *big.Int {
+	if amountOut.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	numerator := new(big.Int).Mul(amountOut, big.NewInt(V2_FEE_DENOMINATOR))
+	repayment := new(big.Int).Div(numerator, big.NewInt(V2_FEE_NUMERATOR))
+	if new(big.Int).Mod(numerator, big.NewInt(V2_FEE_NUMERATOR)).Sign() != 0 {
+		repayment.Add(repayment, big.NewInt(1))
+	}
+	return repayment
+}
+
+func getFlashFees(poolAddress common.Address, amount0 *big.Int, amount1 *big.Int) (*flashFees, error) {
+	if amount0.Sign() < 0 || amount1.Sign() < 0 {
+		return nil, errors.New("amounts should not be negative")
+	}
+	if amount0.Sign() == 0 && amount1.Sign() == 0 {
+		return nil, errors.New("at least one of the amounts should be greater than 0")
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	isV3, err := isV3Pool(client, poolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	fees := &flashFees{IsV3: isV3}
+	if isV3 {
+		pool, err := v3pool.NewV3pool(poolAddress, client)
+		if err != nil {
+			return nil, err
+		}
+
+		liquidity, err := pool.Liquidity(nil)
+		if err != nil {
+			return nil, err
+		}
+		if liquidity.Sign() == 0 {
+			return nil, errors.New("the pool has no liquidity in range, UniswapV3Pool.flash would revert")
+		}
+
+		fees.Fee, err = pool.Fee(nil)
+		if err != nil {
+			return nil, err
+		}
+		fees.Token0, err = pool.Token0(nil)
+		if err != nil {
+			return nil, err
+		}
+		fees.Token1, err = pool.Token1(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// the pool lends out of its token balances, which include unclaimed fees and protocol fees
+		fees.Available0, err = getTokenBalance(fees.Token0, poolAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		fees.Available1, err = getTokenBalance(fees.Token1, poolAddress, client)
+		if err != nil {
+			return nil, err
+		}
+
+		fees.Fee0, err = mulDivRoundingUp(amount0, fees.Fee, FEE_PIPS_DENOMINATOR)
+		if err != nil {
+			return nil, err
+		}
+		fees.Fee1, err = mulDivRoundingUp(amount1, fees.Fee, FEE_PIPS_DENOMINATOR)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pair, err := pairv2.NewPairv2(poolAddress, client)
+		if err != nil {
+			return nil, err
+		}
+
+		reserves, err := pair.GetReserves(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		fees.Token0, err = pair.Token0(nil)
+		if err != nil {
+			return nil, err
+		}
+		fees.Token1, err = pair.Token1(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// UniswapV2Pair.swap requires the amounts out to be strictly less than the reserves
+		fees.Available0 = new(big.Int).Sub(reserves.Reserve0, big.NewInt(1))
+		fees.Available1 = new(big.Int).Sub(reserves.Reserve1, big.NewInt(1))
+		if fees.Available0.Sign() < 0 {
+			fees.Available0 = big.NewInt(0)
+		}
+		if fees.Available1.Sign() < 0 {
+			fees.Available1 = big.NewInt(0)
+		}
+
+		fees.Fee = big.NewInt(V2_FEE_DENOMINATOR - V2_FEE_NUMERATOR)
+		fees.Fee0 = new(big.Int).Sub(getV2FlashRepayment(amount0), amount0)
+		fees.Fee1 = new(big.Int).Sub(getV2FlashRepayment(amount1), amount1)
+	}
+
+	if amount0.Cmp(fees.Available0) > 0 {
+		return nil, fmt.Errorf("amount0 %s is more than the %s of token0 available", formatWei(amount0), formatWei(fees.Available0))
+	}
+	if amount1.Cmp(fees.Available1) > 0 {
+		return nil, fmt.Errorf("amount1 %s is more than the %s of token1 available", formatWei(amount1), formatWei(fees.Available1))
+	}
+
+	return fees, nil
+}
+
+// encodeFlashData builds the callback data passed to the receiver. The spec is either raw hex (0x...) or a comma separated
+// list of TYPE:VALUE, ABI encoded in order, for example address:0x...,uint256:1000,bool:true
+func encodeFlashData(spec string) ([]byte, error) {
+	spec = strings.TrimSpace(spec)
+	if len(spec) == 0 {
+		return []byte{}, nil
+	}
+	if strings.HasPrefix(spec, "0x") || strings.HasPrefix(spec, "0X") {
+		return hexutil.Decode(spec)
+	}
+
+	parts := strings.Split(spec, ",")
+	arguments := make(abi.Arguments, 0, len(parts))
+	values := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		typeAndValue := strings.SplitN(strings.TrimSpace(part), ":", 2)
+		if len(typeAndValue) != 2 {
+			return nil, fmt.Errorf("invalid data item %s, it should be TYPE:VALUE", part)
+		}
+
+		argType, err := abi.NewType(typeAndValue[0], "", nil)
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseAbiValue(argType, typeAndValue[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %s: %w", typeAndValue[0], typeAndValue[1], err)
+		}
+
+		arguments = append(arguments, abi.Argument{Type: argType})
+		values = append(values, value)
+	}
+
+	return arguments.Pack(values...)
+}
+
+// parseAbiValue converts a command line value to the Go type the abi package packs for argType
+func parseAbiValue(argType abi.Type, value string) (interface{}, error) {
+	switch argType.T {
+	case abi.AddressTy:
+		if common.IsHexAddress(value) == false {
+			return nil, errors.New("invalid address")
+		}
+		return common.HexToAddress(value), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.StringTy:
+		return value, nil
+	case abi.BytesTy:
+		return hexutil.Decode(value)
+	case abi.FixedBytesTy:
+		data, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > argType.Size {
+			return nil, fmt.Errorf("more than %d bytes", argType.Size)
+		}
+		array := reflect.New(argType.GetType()).Elem()
+		reflect.Copy(array, reflect.ValueOf(data))
+		return array.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		number, ok := new(big.Int).SetString(value, 0)
+		if ok == false {
+			return nil, errors.New("invalid number")
+		}
+		if argType.T == abi.UintTy {
+			if number.Sign() < 0 || number.BitLen() > argType.Size {
+				return nil, fmt.Errorf("out of range for uint%d", argType.Size)
+			}
+		} else {
+			limit := new(big.Int).Lsh(big.NewInt(1), uint(argType.Size-1))
+			if number.Cmp(new(big.Int).Neg(limit)) < 0 || number.Cmp(limit) >= 0 {
+				return nil, fmt.Errorf("out of range for int%d", argType.Size)
+			}
+		}
+		// the abi package packs the sizes of a Go integer type (8, 16, 32, 64 bits) from that type and all others from *big.Int
+		goType := argType.GetType()
+		if goType == reflect.TypeOf(number) {
+			return number, nil
+		}
+		if argType.T == abi.IntTy {
+			return reflect.ValueOf(number.Int64()).Convert(goType).Interface(), nil
+		}
+		return reflect.ValueOf(number.Uint64()).Convert(goType).Interface(), nil
+	}
+	return nil, errors.New("unsupported type")
+}
+
+// simulateFlash runs the flash borrow with eth_call from the receiver, so that the pool calls the receiver back.
+// An error means the call reverted, usually because the receiver did not repay the amounts plus the fees
+func simulateFlash(poolAddress common.Address, receiver common.Address, amount0 *big.Int, amount1 *big.Int, data []byte) error {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return err
+	}
+
+	isV3, err := isV3Pool(client, poolAddress)
+	if err != nil {
+		return err
+	}
+
+	var out []interface{}
+	callOpts := &bind.CallOpts{From: receiver}
+	if isV3 {
+		contract, err := v3pool.NewV3pool(poolAddress, client)
+		if err != nil {
+			return err
+		}
+		raw := &v3pool.V3poolRaw{Contract: contract}
+		return raw.Call(callOpts, &out, "flash", receiver, amount0, amount1, data)
+	}
+
+	// without callback data the pair does not call the receiver and the call is an ordinary swap
+	if len(data) == 0 {
+		return errors.New("a v2 flash swap needs callback data")
+	}
+
+	contract, err := pairv2.NewPairv2(poolAddress, client)
+	if err != nil {
+		return err
+	}
+	raw := &pairv2.Pairv2Raw{Contract: contract}
+	return raw.Call(callOpts, &out, "swap", amount0, amount1, receiver, data)
+}
+
+// getFlashEvents returns the Flash events of a v3 pool from fromBlock. v2 flash swaps are logged as ordinary Swap events and
+// cannot be told apart from them
+func getFlashEvents(poolAddress common.Address, fromBlock uint64) ([]*v3pool.V3poolFlash, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	isV3, err := isV3Pool(client, poolAddress)
+	if err != nil {
+		return nil, err
+	}
+	if isV3 == false {
+		return nil, errors.New("flash swaps of v2 pairs are logged as Swap events and cannot be audited separately")
+	}
+
+	pool, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	iterator, err := pool.FilterFlash(&bind.FilterOpts{Start: fromBlock}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	events := make([]*v3pool.V3poolFlash, 0)
+	for iterator.Next() {
+		events = append(events, iterator.Event)
+	}
+	if iterator.Error() != nil {
+		return nil, iterator.Error()
+	}
+
+	return events, nil
+}
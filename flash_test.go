@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi"
+)
+
+func TestParseAbiValue(t *testing.T) {
+	tests := []struct {
+		typ   string
+		value string
+		want  interface{}
+		fails bool
+	}{
+		{"uint8", "0", uint8(0), false},
+		{"uint8", "255", uint8(255), false},
+		{"uint8", "256", nil, true},
+		{"uint8", "-1", nil, true},
+		{"int8", "127", int8(127), false},
+		{"int8", "-128", int8(-128), false},
+		{"int8", "128", nil, true},
+		{"int8", "-129", nil, true},
+		{"uint24", "3000", big.NewInt(3000), false},
+		{"uint24", "16777215", big.NewInt(16777215), false},
+		{"uint24", "16777216", nil, true},
+		{"int24", "-887272", big.NewInt(-887272), false},
+		{"int24", "8388607", big.NewInt(8388607), false},
+		{"int24", "8388608", nil, true},
+		{"int24", "-8388609", nil, true},
+		{"uint64", "18446744073709551615", uint64(18446744073709551615), false},
+		{"int64", "-9223372036854775808", int64(-9223372036854775808), false},
+		{"uint160", "0xffffffffffffffffffffffffffffffffffffffff", new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1)), false},
+		{"uint160", "0x10000000000000000000000000000000000000000", nil, true},
+		{"uint256", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", MAX_UINT256, false},
+		{"uint256", "0x10000000000000000000000000000000000000000000000000000000000000000", nil, true},
+		{"int256", "-0x8000000000000000000000000000000000000000000000000000000000000000",
+			new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255)), false},
+		{"uint8", "abc", nil, true},
+	}
+
+	for _, test := range tests {
+		argType, err := abi.NewType(test.typ, "", nil)
+		if err != nil {
+			t.Fatalf("%s: %v", test.typ, err)
+		}
+
+		got, err := parseAbiValue(argType, test.value)
+		if test.fails {
+			if err == nil {
+				t.Errorf("%s:%s: expected an error, got %v", test.typ, test.value, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s:%s: %v", test.typ, test.value, err)
+			continue
+		}
+
+		// the value must pack without the abi package rejecting its Go type
+		_, err = abi.Arguments{{Type: argType}}.Pack(got)
+		if err != nil {
+			t.Errorf("%s:%s: pack: %v", test.typ, test.value, err)
+		}
+
+		if want, ok := test.want.(*big.Int); ok {
+			number, ok := got.(*big.Int)
+			if ok == false || number.Cmp(want) != 0 {
+				t.Errorf("%s:%s: got %T %v, want %v", test.typ, test.value, got, got, want)
+			}
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s:%s: got %T %v, want %T %v", test.typ, test.value, got, got, test.want, test.want)
+		}
+	}
+}
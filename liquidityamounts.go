@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Port of the LiquidityAmounts library of the v3 periphery contracts.
+// Provides functions for computing liquidity amounts from token amounts and prices
+
+var MAX_UINT128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+
+var errLiquidityOverflow = errors.New("liquidity overflows uint128")
+
+func toUint128(x *big.Int) (*big.Int, error) {
+	if x.Sign() < 0 || x.Cmp(MAX_UINT128) > 0 {
+		return nil, errLiquidityOverflow
+	}
+	return x, nil
+}
+
+func sortSqrtRatios(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int) (*big.Int, *big.Int) {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		return sqrtRatioBX96, sqrtRatioAX96
+	}
+	return sqrtRatioAX96, sqrtRatioBX96
+}
+
+// getLiquidityForAmount0 computes the amount of liquidity received for a given amount of token0 and price range
+// Calculates amount0 * (sqrt(upper) * sqrt(lower)) / (sqrt(upper) - sqrt(lower))
+func getLiquidityForAmount0(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, amount0 *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sortSqrtRatios(sqrtRatioAX96, sqrtRatioBX96)
+	intermediate, err := mulDiv(sqrtRatioAX96, sqrtRatioBX96, Q96)
+	if err != nil {
+		return nil, err
+	}
+	liquidity, err := mulDiv(amount0, intermediate, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96))
+	if err != nil {
+		return nil, err
+	}
+	return toUint128(liquidity)
+}
+
+// getLiquidityForAmount1 computes the amount of liquidity received for a given amount of token1 and price range
+// Calculates amount1 / (sqrt(upper) - sqrt(lower)).
+func getLiquidityForAmount1(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, amount1 *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sortSqrtRatios(sqrtRatioAX96, sqrtRatioBX96)
+	liquidity, err := mulDiv(amount1, Q96, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96))
+	if err != nil {
+		return nil, err
+	}
+	return toUint128(liquidity)
+}
+
+// getLiquidityForAmounts computes the maximum amount of liquidity received for a given amount of token0, token1, the current
+// pool prices and the prices at the tick boundaries
+func getLiquidityForAmounts(sqrtRatioX96 *big.Int, sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, amount0 *big.Int, amount1 *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sortSqrtRatios(sqrtRatioAX96, sqrtRatioBX96)
+
+	if sqrtRatioX96.Cmp(sqrtRatioAX96) <= 0 {
+		return getLiquidityForAmount0(sqrtRatioAX96, sqrtRatioBX96, amount0)
+	}
+	if sqrtRatioX96.Cmp(sqrtRatioBX96) < 0 {
+		liquidity0, err := getLiquidityForAmount0(sqrtRatioX96, sqrtRatioBX96, amount0)
+		if err != nil {
+			return nil, err
+		}
+		liquidity1, err := getLiquidityForAmount1(sqrtRatioAX96, sqrtRatioX96, amount1)
+		if err != nil {
+			return nil, err
+		}
+		if liquidity0.Cmp(liquidity1) < 0 {
+			return liquidity0, nil
+		}
+		return liquidity1, nil
+	}
+	return getLiquidityForAmount1(sqrtRatioAX96, sqrtRatioBX96, amount1)
+}
+
+// getAmount0ForLiquidity computes the amount of token0 for a given amount of liquidity and a price range
+func getAmount0ForLiquidity(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sortSqrtRatios(sqrtRatioAX96, sqrtRatioBX96)
+	if sqrtRatioAX96.Sign() == 0 {
+		return nil, errSqrtPriceMath
+	}
+	amount0, err := mulDiv(new(big.Int).Lsh(liquidity, 96), new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96), sqrtRatioBX96)
+	if err != nil {
+		return nil, err
+	}
+	return amount0.Quo(amount0, sqrtRatioAX96), nil
+}
+
+// getAmount1ForLiquidity computes the amount of token1 for a given amount of liquidity and a price range
+func getAmount1ForLiquidity(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int) (*big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sortSqrtRatios(sqrtRatioAX96, sqrtRatioBX96)
+	return mulDiv(liquidity, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96), Q96)
+}
+
+// getAmountsForLiquidity computes the token0 and token1 value for a given amount of liquidity, the current
+// pool prices and the prices at the tick boundaries
+func getAmountsForLiquidity(sqrtRatioX96 *big.Int, sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int) (*big.Int, *big.Int, error) {
+	sqrtRatioAX96, sqrtRatioBX96 = sortSqrtRatios(sqrtRatioAX96, sqrtRatioBX96)
+
+	if sqrtRatioX96.Cmp(sqrtRatioAX96) <= 0 {
+		amount0, err := getAmount0ForLiquidity(sqrtRatioAX96, sqrtRatioBX96, liquidity)
+		return amount0, big.NewInt(0), err
+	}
+	if sqrtRatioX96.Cmp(sqrtRatioBX96) < 0 {
+		amount0, err := getAmount0ForLiquidity(sqrtRatioX96, sqrtRatioBX96, liquidity)
+		if err != nil {
+			return nil, nil, err
+		}
+		amount1, err := getAmount1ForLiquidity(sqrtRatioAX96, sqrtRatioX96, liquidity)
+		return amount0, amount1, err
+	}
+	amount1, err := getAmount1ForLiquidity(sqrtRatioAX96, sqrtRatioBX96, liquidity)
+	return big.NewInt(0), amount1, err
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Vectors from LiquidityAmounts.spec.ts of the v3 periphery contracts, for the range encodePriceSqrt(100, 110) to encodePriceSqrt(110, 100)
+func TestLiquidityAmounts(t *testing.T) {
+	sqrtRatioA := fromDecimalString("75541088972021052632782079082") // encodePriceSqrt(100, 110)
+	sqrtRatioB := fromDecimalString("83095197869223157896060286990") // encodePriceSqrt(110, 100)
+
+	tests := []struct {
+		name      string
+		sqrtRatio *big.Int
+		liquidity int64
+		amount0   int64
+		amount1   int64
+	}{
+		{"price inside", fromDecimalString("79228162514264337593543950336"), 2148, 99, 99}, // encodePriceSqrt(1, 1)
+		{"price below", fromDecimalString("75162434512514379355924140470"), 1048, 99, 0},   // encodePriceSqrt(99, 110)
+		{"price above", fromDecimalString("83472048772503575395058907992"), 2097, 0, 199},  // encodePriceSqrt(111, 100)
+		{"price on the lower boundary", sqrtRatioA, 1048, 99, 0},
+		{"price on the upper boundary", sqrtRatioB, 2097, 0, 199},
+	}
+
+	for _, test := range tests {
+		// amounts of 100 token0 and 200 token1, in either order of the boundaries
+		for _, bounds := range [][2]*big.Int{{sqrtRatioA, sqrtRatioB}, {sqrtRatioB, sqrtRatioA}} {
+			liquidity, err := getLiquidityForAmounts(test.sqrtRatio, bounds[0], bounds[1], big.NewInt(100), big.NewInt(200))
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+				continue
+			}
+			if liquidity.Int64() != test.liquidity {
+				t.Errorf("%s: liquidity %v, want %d", test.name, liquidity, test.liquidity)
+			}
+
+			amount0, amount1, err := getAmountsForLiquidity(test.sqrtRatio, bounds[0], bounds[1], big.NewInt(test.liquidity))
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+				continue
+			}
+			if amount0.Int64() != test.amount0 || amount1.Int64() != test.amount1 {
+				t.Errorf("%s: amounts %v %v, want %d %d", test.name, amount0, amount1, test.amount0, test.amount1)
+			}
+		}
+	}
+}
+
+func TestGetLiquidityForAmountsOverflow(t *testing.T) {
+	// MAX_UINT128 of token0 in a range one tick wide needs more liquidity than a uint128 holds
+	sqrtRatioA, err := getSqrtRatioAtTick(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqrtRatioB, err := getSqrtRatioAtTick(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = getLiquidityForAmounts(sqrtRatioA, sqrtRatioA, sqrtRatioB, MAX_UINT128, big.NewInt(0))
+	if err != errLiquidityOverflow {
+		t.Errorf("error %v, want %v", err, errLiquidityOverflow)
+	}
+}
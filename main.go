@@ -2,13 +2,17 @@ package main
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
 
+	"quantumswap-cli/contracts/v3staker"
+
 	"github.com/quantumcoinproject/quantum-coin-go/common"
 	"github.com/quantumcoinproject/quantum-coin-go/console/prompt"
+	"github.com/quantumcoinproject/quantum-coin-go/params"
 )
 
 var rawURL string
@@ -38,55 +42,289 @@ func printHelp() {
 	fmt.Println("            SWAP_ROUTER_V2_CONTRACT_ADDRESS")
 
 	fmt.Println("(optional) quantumswap-deploy swapexacttokensFortokens TOKEN_IN_ADDRESS TOKEN_OUT_ADDRESS AMOUNT_IN AMOUNT_OUT_MIN")
-	fmt.Println(" FEE should be 500 or 3000 or 10000 (For 0.3, 0.05%, 0.3%, or 1%)")
 	fmt.Println("      Set the following environment variables:")
 	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
 	fmt.Println("      Set the following additional environment variables:")
 	fmt.Println("            SWAP_ROUTER_V2_CONTRACT_ADDRESS")
 
 	fmt.Println("(optional) quantumswap-cli createpool TOKEN_A_ADDRESS TOKEN_B_ADDRESS FEE")
-	fmt.Println(" FEE should be 500 or 3000 or 10000 (For 0.3, 0.05%, 0.3%, or 1%)")
+	fmt.Println(" FEE can be any fee tier enabled in the factory, for example 100, 500, 3000 or 10000 (For 0.01%, 0.05%, 0.3%, or 1%), see feetiers")
 	fmt.Println("      Set the following environment variables:")
 	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
 	fmt.Println("      Set the following additional environment variables:")
 	fmt.Println("           V3_CORE_FACTORY_CONTRACT_ADDRESS")
 
 	fmt.Println("(optional) quantumswap-cli getpool TOKEN_A_ADDRESS TOKEN_B_ADDRESS FEE")
-	fmt.Println(" FEE should be 500 or 3000 or 10000 (For 0.3, 0.05%, 0.3%, or 1%)")
+	fmt.Println(" FEE can be any fee tier enabled in the factory, for example 100, 500, 3000 or 10000 (For 0.01%, 0.05%, 0.3%, or 1%), see feetiers")
 	fmt.Println("      Set the following environment variables:")
 	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT")
 	fmt.Println("      Set the following additional environment variables:")
 	fmt.Println("           V3_CORE_FACTORY_CONTRACT_ADDRESS")
 
+	fmt.Println("(optional) quantumswap-cli feetiers")
+	fmt.Println(" Lists the fee tiers enabled in the factory with their tick spacing")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V3_CORE_FACTORY_CONTRACT_ADDRESS")
+
 	fmt.Println("(optional) quantumswap-cli initializepool POOL_ADDRESS PRICE_IN_TOKEN_B_PER_TOKEN_A TOKEN_A_DECIMALS TOKEN_B_DECIMALS")
+	fmt.Println(" TOKEN_A is the pool's token0 and TOKEN_B is the pool's token1. PRICE may be fractional, for example 0.25 or 1/4")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-deploy addliquidityv3 TOKEN_A_ADDRESS TOKEN_B_ADDRESS FEE RANGE_LOWER RANGE_UPPER AMOUNT_A AMOUNT_B AMOUNT_A_MIN AMOUNT_B_MIN")
+	fmt.Println(" RANGE_LOWER and RANGE_UPPER can each be a tick (-600), a price of token B per token A (price:1.25),")
+	fmt.Println(" a percentage around the current price (-10%, +10%), or min and max for the full range.")
+	fmt.Println(" The range is aligned to the tick spacing of the pool.")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
+	fmt.Println(" FEE can be any fee tier enabled in the factory, for example 100, 500, 3000 or 10000 (For 0.01%, 0.05%, 0.3%, or 1%), see feetiers")
 	fmt.Println("      Set the following environment variables:")
 	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           NONFUNGIBLE_POSITION_MANAGER_CONTRACT_ADDRESS")
 
-	fmt.Println("(optional) quantumswap-deploy addliquidityv3 TOKEN_A_ADDRESS TOKEN_B_ADDRESS FEE TICK_LOWER TICK_UPPER AMOUNT_A AMOUNT_B AMOUNT_A_MIN AMOUNT_B_MIN")
+	fmt.Println("(optional) quantumswap-cli launchpool TOKEN_A_ADDRESS TOKEN_B_ADDRESS FEE PRICE_IN_TOKEN_B_PER_TOKEN_A MIN_PRICE MAX_PRICE AMOUNT_A AMOUNT_B AMOUNT_A_MIN AMOUNT_B_MIN")
+	fmt.Println(" Creates and initializes the pool if necessary and adds liquidity in a single transaction.")
 	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
-	fmt.Println(" FEE should be 500 or 3000 or 10000 (For 0.3, 0.05%, 0.3%, or 1%)")
+	fmt.Println(" FEE can be any fee tier enabled in the factory, for example 100, 500, 3000 or 10000 (For 0.01%, 0.05%, 0.3%, or 1%), see feetiers")
 	fmt.Println("      Set the following environment variables:")
 	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
 	fmt.Println("      Set the following additional environment variables:")
 	fmt.Println("           NONFUNGIBLE_POSITION_MANAGER_CONTRACT_ADDRESS")
 
-	fmt.Println("(optional) quantumswap-deploy exactinputsingle TOKEN_IN_ADDRESS TOKEN_OUT_ADDRESS FEE AMOUNT_IN AMOUNT_OUT_MIN")
-	fmt.Println(" FEE should be 500 or 3000 or 10000 (For 0.3, 0.05%, 0.3%, or 1%)")
+	fmt.Println("(optional) quantumswap-cli v3deposit POOL_ADDRESS TICK_LOWER TICK_UPPER TOKEN_ADDRESS AMOUNT [SLIPPAGE_PERCENT]")
+	fmt.Println(" Calculates the amount of the other token, the liquidity and the amount mins for depositing AMOUNT of TOKEN_ADDRESS into the range.")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
+	fmt.Println(" SLIPPAGE_PERCENT defaults to the --slippage percentage, or 0.5")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+
+	fmt.Println("(optional) quantumswap-deploy exactinputsingle TOKEN_IN_ADDRESS TOKEN_OUT_ADDRESS FEE AMOUNT_IN AMOUNT_OUT_MIN [LIMIT_PRICE]")
+	fmt.Println(" LIMIT_PRICE is the lowest price, in TOKEN_OUT per TOKEN_IN, the pool is allowed to reach. The swap stops there and only part of AMOUNT_IN may be used.")
+	fmt.Println(" FEE can be any fee tier enabled in the factory, for example 100, 500, 3000 or 10000 (For 0.01%, 0.05%, 0.3%, or 1%), see feetiers")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           SWAP_ROUTER_CONTRACT_ADDRESS")
+	fmt.Println("      Set QUOTER_V2_CONTRACT_ADDRESS to see a quote before confirming")
+
+	fmt.Println("(optional) quantumswap-deploy exactoutputsingle TOKEN_IN_ADDRESS TOKEN_OUT_ADDRESS FEE AMOUNT_OUT AMOUNT_IN_MAX [LIMIT_PRICE]")
+	fmt.Println(" LIMIT_PRICE is the lowest price, in TOKEN_OUT per TOKEN_IN, the pool is allowed to reach. The swap stops there and less than AMOUNT_OUT may be received.")
+	fmt.Println(" FEE can be any fee tier enabled in the factory, for example 100, 500, 3000 or 10000 (For 0.01%, 0.05%, 0.3%, or 1%), see feetiers")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           SWAP_ROUTER_CONTRACT_ADDRESS")
+	fmt.Println("      Set QUOTER_V2_CONTRACT_ADDRESS to see a quote before confirming")
+
+	fmt.Println("(optional) quantumswap-cli exactinput ROUTE AMOUNT_IN AMOUNT_OUT_MIN")
+	fmt.Println(" ROUTE is a multi-hop path of tokens and fees, for example TOKEN_A_ADDRESS:3000:WQ:500:TOKEN_B_ADDRESS")
+	fmt.Println(" WQ can be used in place of the wrapped Q token address. Every hop's pool must exist.")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
 	fmt.Println("      Set the following environment variables:")
 	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
 	fmt.Println("      Set the following additional environment variables:")
 	fmt.Println("           SWAP_ROUTER_CONTRACT_ADDRESS")
+	fmt.Println("      Set QUOTER_V2_CONTRACT_ADDRESS to quote the route without holding or approving the input token")
 
-	fmt.Println("(optional) quantumswap-deploy exactoutputsingle TOKEN_IN_ADDRESS TOKEN_OUT_ADDRESS FEE AMOUNT_OUT AMOUNT_IN_MAX")
-	fmt.Println(" FEE should be 500 or 3000 or 10000 (For 0.3, 0.05%, 0.3%, or 1%)")
+	fmt.Println("(optional) quantumswap-cli exactoutput ROUTE AMOUNT_OUT AMOUNT_IN_MAX")
+	fmt.Println(" ROUTE is given from the input token to the output token, for example TOKEN_A_ADDRESS:3000:WQ:500:TOKEN_B_ADDRESS")
+	fmt.Println(" WQ can be used in place of the wrapped Q token address. Every hop's pool must exist.")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
 	fmt.Println("      Set the following environment variables:")
 	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
 	fmt.Println("      Set the following additional environment variables:")
 	fmt.Println("           SWAP_ROUTER_CONTRACT_ADDRESS")
+	fmt.Println("      Set QUOTER_V2_CONTRACT_ADDRESS to quote the route without holding or approving the input token")
+
+	fmt.Println("(optional) quantumswap-cli quotev3 exactin|exactout ROUTE AMOUNT")
+	fmt.Println(" Quotes a swap of AMOUNT in (exactin) or out (exactout) along ROUTE without sending a transaction.")
+	fmt.Println(" ROUTE is a single or multi-hop path of tokens and fees, for example TOKEN_A_ADDRESS:3000:WQ:500:TOKEN_B_ADDRESS")
+	fmt.Println(" Reports the quoted amount, the price of each pool after the swap, the initialized ticks crossed, the gas estimate and the price impact")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           QUOTER_V2_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli simulatev3 POOL_ADDRESS TOKEN_ADDRESS exactin|exactout AMOUNT[,AMOUNT...]")
+	fmt.Println(" Loads the pool state once and simulates swapping each AMOUNT of TOKEN_ADDRESS in (exactin) or of the other token out (exactout) off-chain.")
+	fmt.Println(" Reports the amounts, the fee, the price after the swap and the initialized ticks crossed. Swaps larger than the liquidity are reported as partial fills.")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+	fmt.Println("      Set QUOTER_V2_CONTRACT_ADDRESS to check every result against QuoterV2")
+
+	fmt.Println("(optional) quantumswap-cli twap POOL_ADDRESS WINDOW_SECONDS")
+	fmt.Println(" Returns the time weighted average tick and price and the harmonic mean liquidity of the pool over the last WINDOW_SECONDS.")
+	fmt.Println(" The window can not be older than the oldest observation stored by the pool, see increasecardinality.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+
+	fmt.Println("(optional) quantumswap-cli increasecardinality POOL_ADDRESS CARDINALITY")
+	fmt.Println(" Grows the oracle observations buffer of the pool to CARDINALITY (at most 65535), so that longer TWAP windows can be queried.")
+	fmt.Println(" Every new slot costs gas; grow the buffer in several steps if the transaction runs out of gas.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli admin owner")
+	fmt.Println("(optional) quantumswap-cli admin enablefeeamount FEE TICK_SPACING")
+	fmt.Println("(optional) quantumswap-cli admin setowner NEW_OWNER_ADDRESS")
+	fmt.Println(" Owner actions of the v3 factory. FROM_ADDRESS must be the owner of the factory.")
+	fmt.Println(" FEE is in hundredths of a bip and less than 1000000, TICK_SPACING is between 1 and 16383. Fee tiers can not be disabled once enabled.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V3_CORE_FACTORY_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli admin feeprotocol POOL_ADDRESS")
+	fmt.Println("(optional) quantumswap-cli admin setfeeprotocol POOL_ADDRESS FEE_PROTOCOL0 FEE_PROTOCOL1")
+	fmt.Println("(optional) quantumswap-cli admin collectprotocol POOL_ADDRESS RECIPIENT_ADDRESS")
+	fmt.Println(" Protocol fee actions of a v3 pool. FROM_ADDRESS must be the owner of the factory of the pool.")
+	fmt.Println(" FEE_PROTOCOL0 and FEE_PROTOCOL1 are 0 (off) or between 4 and 10; the protocol takes 1/FEE_PROTOCOL of the swap fees of that token.")
+	fmt.Println(" collectprotocol sends all the protocol fees accrued by the pool to RECIPIENT_ADDRESS.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli admin v2feeto")
+	fmt.Println("(optional) quantumswap-cli admin v2setfeeto FEE_TO_ADDRESS|off")
+	fmt.Println("(optional) quantumswap-cli admin v2setfeetosetter NEW_FEE_TO_SETTER_ADDRESS")
+	fmt.Println(" Fee switch of the v2 factory. Setting FEE_TO_ADDRESS turns the protocol fee (1/6th of the swap fees) on, off turns it off.")
+	fmt.Println(" FROM_ADDRESS must be the feeToSetter of the factory.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V2_CORE_FACTORY_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli admin v2protocolfees")
+	fmt.Println(" Estimates, for every pair, the LP tokens accrued to feeTo since kLast was recorded, and the LP tokens feeTo already holds.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V2_CORE_FACTORY_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli staker createincentive REWARD_TOKEN_ADDRESS POOL_ADDRESS START_TIME END_TIME REFUNDEE_ADDRESS REWARD")
+	fmt.Println(" START_TIME and END_TIME are unix times in seconds, or seconds from now when prefixed with +, for example +3600")
+	fmt.Println(" The start can be at most 30 days from now and the incentive can last at most 2 years. REWARD_TOKEN_ADDRESS must be approved for V3_STAKER_CONTRACT_ADDRESS.")
+	fmt.Println(" Prints the INCENTIVE (REWARD_TOKEN_ADDRESS:POOL_ADDRESS:START_TIME:END_TIME:REFUNDEE_ADDRESS) used by the other staker commands")
+	fmt.Println("(optional) quantumswap-cli staker incentive INCENTIVE")
+	fmt.Println("(optional) quantumswap-cli staker deposit TOKEN_ID [INCENTIVE]")
+	fmt.Println("(optional) quantumswap-cli staker stake TOKEN_ID INCENTIVE")
+	fmt.Println("(optional) quantumswap-cli staker unstake TOKEN_ID INCENTIVE")
+	fmt.Println("(optional) quantumswap-cli staker rewardinfo TOKEN_ID INCENTIVE")
+	fmt.Println("(optional) quantumswap-cli staker rewards REWARD_TOKEN_ADDRESS")
+	fmt.Println("(optional) quantumswap-cli staker claim REWARD_TOKEN_ADDRESS RECIPIENT_ADDRESS [AMOUNT]")
+	fmt.Println("(optional) quantumswap-cli staker withdraw TOKEN_ID RECIPIENT_ADDRESS")
+	fmt.Println("(optional) quantumswap-cli staker endincentive INCENTIVE")
+	fmt.Println(" claim claims all the rewards owed to FROM_ADDRESS when AMOUNT is not given")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V3_STAKER_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli migrate PAIR_ADDRESS PERCENT FEE RANGE_LOWER RANGE_UPPER [SLIPPAGE_PERCENT]")
+	fmt.Println(" Moves PERCENT (1 to 100) of the v2 LP tokens of FROM_ADDRESS in PAIR_ADDRESS into a v3 position of the FEE pool of the same tokens.")
+	fmt.Println(" RANGE_LOWER and RANGE_UPPER take the same forms as addliquidityv3 (tick, price:1.25, -10%, min, max), with token A being token0 of the pair.")
+	fmt.Println(" The v3 pool is created and initialized at the v2 price when needed. The tokens the position does not use are refunded.")
+	fmt.Println(" SLIPPAGE_PERCENT (--slippage, or 0.5 by default) sets the amount mins of the v3 position.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V3_MIGRATOR_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli flash fee POOL_ADDRESS AMOUNT0 AMOUNT1")
+	fmt.Println("(optional) quantumswap-cli flash simulate POOL_ADDRESS RECEIVER_ADDRESS AMOUNT0 AMOUNT1 [DATA]")
+	fmt.Println("(optional) quantumswap-cli flash events POOL_ADDRESS [FROM_BLOCK]")
+	fmt.Println(" POOL_ADDRESS can be a v3 pool (flash loan) or a v2 pair (flash swap). AMOUNT0 and AMOUNT1 are the amounts of token0 and token1 to borrow.")
+	fmt.Println(" fee prints the fees to pay back on top of the amounts borrowed. simulate runs the flash borrow with eth_call from RECEIVER_ADDRESS,")
+	fmt.Println(" which is called back with DATA and must repay the amounts plus the fees. DATA is raw hex (0x...) or TYPE:VALUE items")
+	fmt.Println(" separated by commas that are ABI encoded, for example address:0x...,uint256:1000. v2 flash swaps need DATA.")
+	fmt.Println(" events lists the Flash events of a v3 pool from FROM_BLOCK (0 by default).")
+	fmt.Println(" !!!LIMITATION!!! Amount will be converted to wei based on 18 decimals internally. Other decimals not supported.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+
+	fmt.Println("(optional) quantumswap-cli deploy ARTIFACTS_DIR [STATE_FILE] [ADDRESS_BOOK_FILE]")
+	fmt.Println(" Deploys the whole QuantumSwap stack in dependency order, linking the NFTDescriptor library into the position descriptor.")
+	fmt.Println(" Contracts without bytecode in this repository are read from ARTIFACTS_DIR/<Contract>.abi and ARTIFACTS_DIR/<Contract>.bin (solc output).")
+	fmt.Println(" STATE_FILE (deployment-state.json by default) records each deployment; run deploy again with it to resume after a failure.")
+	fmt.Println(" ADDRESS_BOOK_FILE (addressbook.env by default) receives the contract addresses. Set DP_ADDRESS_BOOK to it to use them in every other command.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,GAS_LIMIT,FROM_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli verify-deployment [ARTIFACTS_DIR]")
+	fmt.Println(" Checks the configured contract addresses: that each has code matching ARTIFACTS_DIR/<Contract>.json (the solc standard JSON output")
+	fmt.Println(" of the contract, whose immutableReferences and linkReferences are masked) or ARTIFACTS_DIR/<Contract>.bin-runtime (compared as is;")
+	fmt.Println(" the code is not compared without ARTIFACTS_DIR), that the routers and the position manager point at the configured contracts, that INIT_CODE_HASH")
+	fmt.Println(" of the v2 factory matches the pair bytecode and that the transparent proxy's admin and implementation are as configured.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+	fmt.Println("      Set the contract address environment variables written by deploy, or DP_ADDRESS_BOOK")
+
+	fmt.Println("(optional) quantumswap-cli computepair TOKEN_A_ADDRESS TOKEN_B_ADDRESS [check]")
+	fmt.Println(" Computes the v2 pair address offline from V2_CORE_FACTORY_CONTRACT_ADDRESS, the sorted tokens and the hash of the pair bytecode (INIT_CODE_HASH).")
+	fmt.Println(" With check, the address and INIT_CODE_HASH are compared with the factory, which needs DP_RAW_URL.")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V2_CORE_FACTORY_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli computepool TOKEN_A_ADDRESS TOKEN_B_ADDRESS FEE [check]")
+	fmt.Println(" Computes the v3 pool address offline from V3_CORE_FACTORY_CONTRACT_ADDRESS, the sorted tokens, FEE and the hash of the pool bytecode.")
+	fmt.Println(" With check, the address is compared with getPool of the factory, which needs DP_RAW_URL.")
+	fmt.Println("      Set the following additional environment variables:")
+	fmt.Println("           V3_CORE_FACTORY_CONTRACT_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli nonce [reset]")
+	fmt.Println(" Transactions take their nonce from a nonce manager shared by every quantumswap-cli process, so that transactions sent back to back")
+	fmt.Println(" do not collide. nonce shows the nonces of FROM_ADDRESS in flight and the gaps left by dropped transactions, which the next transaction fills.")
+	fmt.Println(" reset forgets the nonces issued to FROM_ADDRESS. The state is kept in DP_NONCE_FILE (~/.quantumswap-cli/quantumswap-nonces.json by default).")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, FROM_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli speedup TX_HASH [BUMP_PERCENT]")
+	fmt.Println("(optional) quantumswap-cli cancel TX_HASH [BUMP_PERCENT]")
+	fmt.Println(" Replaces a pending transaction of FROM_ADDRESS at the same nonce, with the gas price raised by BUMP_PERCENT (at least and by default 10).")
+	fmt.Println(" speedup sends the same transaction again; cancel sends a zero-value transfer to FROM_ADDRESS instead.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           CHAIN_ID, DP_RAW_URL, DP_KEY_FILE_DIR or DP_KEY_FILE,FROM_ADDRESS")
+
+	fmt.Println("(optional) quantumswap-cli SWAP_OR_LIQUIDITY_COMMAND ... --slippage PERCENT")
+	fmt.Println(" Derives AMOUNT_OUT_MIN, AMOUNT_IN_MAX, AMOUNT_A_MIN and AMOUNT_B_MIN from a quote instead of taking them as arguments; leave them out.")
+	fmt.Println(" The swaps are quoted with the router, or with QuoterV2 (QUOTER_V2_CONTRACT_ADDRESS) for exactinputsingle and exactoutputsingle.")
+	fmt.Println(" The liquidity commands use the amounts the pair or pool would take at its current price. The bounds are shown before confirming.")
+	fmt.Println("(optional) quantumswap-cli WRITE_COMMAND ... --deadline DURATION")
+	fmt.Println(" Swaps, addliquidity, addliquidityv3 and migrate revert when they are mined later than DURATION (such as 90s, 10m or 1h)")
+	fmt.Println(" after the timestamp of the latest block. The default is 20m. v3 swaps are sent through multicall(deadline, data) of the router.")
+	fmt.Println("(optional) quantumswap-cli WRITE_COMMAND ... --unsigned FILE")
+	fmt.Println(" Any command that sends a transaction writes it unsigned (to, data, value, nonce, gas, gas price, chain id) to FILE as JSON instead,")
+	fmt.Println(" without a key file. FROM_ADDRESS is the account that will sign it. Its nonce is held, however long signing takes, until the")
+	fmt.Println(" transaction is mined; run nonce reset if it will not be sent.")
+	fmt.Println("(optional) quantumswap-cli WRITE_COMMAND ... --calldata")
+	fmt.Println(" Any command that sends a transaction prints the target address, value and ABI encoded input instead, with the decoded method")
+	fmt.Println(" and arguments, for a multisig or governance proposal. No key file is needed; set FROM_ADDRESS to the multisig.")
+	fmt.Println(" migrate prints the LP token approval it needs before the migration, in the order to execute them. deploy does not accept it.")
+	fmt.Println("(optional) quantumswap-cli sign UNSIGNED_TX_FILE SIGNED_TX_FILE")
+	fmt.Println(" Signs an unsigned transaction offline with DP_KEY_FILE, without connecting to a node, and writes the raw signed transaction.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_KEY_FILE, DP_ACC_PWD (prompted when not set)")
+	fmt.Println("(optional) quantumswap-cli broadcast SIGNED_TX_FILE")
+	fmt.Println(" Sends a raw signed transaction and waits for its receipt.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL")
+
+	fmt.Println("(optional) quantumswap-cli decode TX_HASH|CALLDATA")
+	fmt.Println(" Decodes the input of a transaction, or ABI encoded calldata, against the routers, factories, pair, pool, position manager,")
+	fmt.Println(" quoter, migrator and staker ABIs. The calls packed in a multicall and v3 swap paths are decoded too, with token symbols")
+	fmt.Println(" and amounts in token units when DP_RAW_URL is reachable.")
+	fmt.Println("      Set the following environment variables:")
+	fmt.Println("           DP_RAW_URL (for TX_HASH)")
 
 	fmt.Println("(optional) quantumswap-deploy ticktoprice TICK")
+	fmt.Println(" TICK is clamped to the range -887272 to 887272")
 
 	fmt.Println("(optional) quantumswap-deploy pricetotick PRICE")
+	fmt.Println(" PRICE may be fractional, for example 0.25 or 1/4. Returns the greatest tick whose price is less than or equal to PRICE")
 }
 
 func main() {
@@ -94,6 +332,12 @@ func main() {
 	fmt.Println(" QuantumSwap CLI")
 	fmt.Println("===================")
 
+	err := parseGlobalFlags()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
 	if len(os.Args) < 2 {
 		printHelp()
 		return
@@ -109,6 +353,15 @@ func main() {
 		}
 	}
 
+	addressBook := os.Getenv(ADDRESS_BOOK_ENV)
+	if len(addressBook) > 0 {
+		err := loadAddressBook(addressBook)
+		if err != nil {
+			fmt.Println("Error loading", ADDRESS_BOOK_ENV, addressBook, err)
+			return
+		}
+	}
+
 	if os.Args[1] == "createpair" {
 		CreatePair()
 	} else if os.Args[1] == "getpair" {
@@ -121,14 +374,60 @@ func main() {
 		CreatePool()
 	} else if os.Args[1] == "getpool" {
 		GetPool()
+	} else if os.Args[1] == "feetiers" {
+		FeeTiers()
 	} else if os.Args[1] == "initializepool" {
 		InitializePool()
 	} else if os.Args[1] == "addliquidityv3" {
 		AddLiquidityV3()
+	} else if os.Args[1] == "launchpool" {
+		LaunchPool()
+	} else if os.Args[1] == "v3deposit" {
+		V3Deposit()
 	} else if os.Args[1] == "exactinputsingle" {
 		ExactInputSingle()
 	} else if os.Args[1] == "exactoutputsingle" {
 		ExactOutputSingle()
+	} else if os.Args[1] == "exactinput" {
+		ExactInput()
+	} else if os.Args[1] == "exactoutput" {
+		ExactOutput()
+	} else if os.Args[1] == "quotev3" {
+		QuoteV3()
+	} else if os.Args[1] == "simulatev3" {
+		SimulateV3()
+	} else if os.Args[1] == "twap" {
+		Twap()
+	} else if os.Args[1] == "increasecardinality" {
+		IncreaseCardinality()
+	} else if os.Args[1] == "admin" {
+		Admin()
+	} else if os.Args[1] == "staker" {
+		Staker()
+	} else if os.Args[1] == "migrate" {
+		Migrate()
+	} else if os.Args[1] == "flash" {
+		Flash()
+	} else if os.Args[1] == "deploy" {
+		Deploy()
+	} else if os.Args[1] == "verify-deployment" {
+		VerifyDeployment()
+	} else if os.Args[1] == "computepair" {
+		ComputePair()
+	} else if os.Args[1] == "computepool" {
+		ComputePool()
+	} else if os.Args[1] == "nonce" {
+		Nonce()
+	} else if os.Args[1] == "speedup" {
+		ReplaceTransaction(false)
+	} else if os.Args[1] == "cancel" {
+		ReplaceTransaction(true)
+	} else if os.Args[1] == "sign" {
+		Sign()
+	} else if os.Args[1] == "broadcast" {
+		Broadcast()
+	} else if os.Args[1] == "decode" {
+		Decode()
 	} else if os.Args[1] == "ticktoprice" {
 		TickToPrice()
 	} else if os.Args[1] == "pricetotick" {
@@ -184,7 +483,7 @@ func CreatePair() {
 
 	_, err = createPair(tokenAaddress, tokenBaddress)
 	if err != nil {
-		fmt.Println("createPair error", err)
+		printCommandError("createPair error", err)
 		return
 	}
 }
@@ -224,7 +523,11 @@ func GetPair() {
 }
 
 func AddLiquidityV2() {
-	if len(os.Args) < 8 {
+	argCount := 8
+	if slippageTolerance != nil {
+		argCount = 6
+	}
+	if len(os.Args) < argCount {
 		printHelp()
 		return
 	}
@@ -257,18 +560,19 @@ func AddLiquidityV2() {
 		return
 	}
 
-	amountAminval := os.Args[6]
-	amountAmin, err := strconv.ParseUint(amountAminval, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing AMOUNT_A_MIN", err)
-		return
-	}
+	var amountAmin, amountBmin *big.Int
+	if slippageTolerance == nil {
+		amountAmin, err = parseAmountBound(os.Args[6])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_A_MIN", err)
+			return
+		}
 
-	amountBminval := os.Args[7]
-	amountBmin, err := strconv.ParseUint(amountBminval, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing AMOUNT_B_MIN", err)
-		return
+		amountBmin, err = parseAmountBound(os.Args[7])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_B_MIN", err)
+			return
+		}
 	}
 
 	v2SwapRouterContractAddr := os.Getenv("SWAP_ROUTER_V2_CONTRACT_ADDRESS")
@@ -285,8 +589,24 @@ func AddLiquidityV2() {
 	}
 	fromAddress = common.HexToAddress(fromAddr)
 
+	amountAwei := params.EtherToWei(new(big.Int).SetUint64(amountA))
+	amountBwei := params.EtherToWei(new(big.Int).SetUint64(amountB))
+	if slippageTolerance != nil {
+		expectedA, expectedB, err := getV2LiquidityAmounts(tokenAaddress, tokenBaddress, amountAwei, amountBwei)
+		if err != nil {
+			fmt.Println("getV2LiquidityAmounts error", err)
+			return
+		}
+		amountAmin = applySlippage(expectedA, slippageTolerance)
+		amountBmin = applySlippage(expectedB, slippageTolerance)
+		fmt.Println("Expected deposit", "amountA", formatWei(expectedA), "amountB", formatWei(expectedB), "slippagePercent", slippageTolerance.FloatString(2))
+	} else {
+		warnIfNoProtection("AMOUNT_A_MIN", amountAmin, amountAwei)
+		warnIfNoProtection("AMOUNT_B_MIN", amountBmin, amountBwei)
+	}
+
 	fmt.Println("addLiquidityV2", "v2SwapRouterContractAddr", v2SwapRouterContractAddr, "tokenAaddress", tokenAaddress, "tokenBaddress", tokenBaddress,
-		"amountA", amountA, "amountB", amountB, "amountAmin", amountAmin, "amountBmin", amountBmin)
+		"amountA", amountA, "amountB", amountB, "amountAmin", formatWei(amountAmin), "amountBmin", formatWei(amountBmin))
 
 	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to AddLiquidityV2 from %s?", fromAddress))
 	if err != nil {
@@ -298,15 +618,19 @@ func AddLiquidityV2() {
 		return
 	}
 
-	_, err = addLiquidityV2(tokenAaddress, tokenBaddress, int64(amountA), int64(amountB), int64(amountAmin), int64(amountBmin))
+	_, err = addLiquidityV2(tokenAaddress, tokenBaddress, int64(amountA), int64(amountB), amountAmin, amountBmin)
 	if err != nil {
-		fmt.Println("addLiquidityV2 error", err)
+		printCommandError("addLiquidityV2 error", err)
 		return
 	}
 }
 
 func SwapExactTokensForTokens() {
-	if len(os.Args) < 6 {
+	argCount := 6
+	if slippageTolerance != nil {
+		argCount = 5
+	}
+	if len(os.Args) < argCount {
 		printHelp()
 		return
 	}
@@ -332,11 +656,13 @@ func SwapExactTokensForTokens() {
 		return
 	}
 
-	amountOutMinVal := os.Args[5]
-	amountOutMin, err := strconv.ParseUint(amountOutMinVal, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing AMOUNT_OUT_MIN", err)
-		return
+	var amountOutMin *big.Int
+	if slippageTolerance == nil {
+		amountOutMin, err = parseAmountBound(os.Args[5])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_OUT_MIN", err)
+			return
+		}
 	}
 
 	v2SwapRouterContractAddr := os.Getenv("SWAP_ROUTER_V2_CONTRACT_ADDRESS")
@@ -353,8 +679,23 @@ func SwapExactTokensForTokens() {
 	}
 	fromAddress = common.HexToAddress(fromAddr)
 
+	amountOut, err := getV2SwapQuote(tokenInAddress, tokenOutAddress, params.EtherToWei(new(big.Int).SetUint64(amountIn)))
+	if err != nil {
+		fmt.Println("Error quoting swap", err)
+		if slippageTolerance != nil {
+			return
+		}
+	} else {
+		fmt.Println("Quoted amount out", formatWei(amountOut))
+	}
+	if slippageTolerance != nil {
+		amountOutMin = applySlippage(amountOut, slippageTolerance)
+	} else {
+		warnIfNoProtection("AMOUNT_OUT_MIN", amountOutMin, amountOut)
+	}
+
 	fmt.Println("SwapExactTokensForTokens", "v2SwapRouterContractAddr", v2SwapRouterContractAddr, "tokenInaddr", tokenInaddr, "tokenOutAddress", tokenOutAddress,
-		"amountInVal", amountInVal, "amountOutMinVal", amountOutMinVal)
+		"amountInVal", amountInVal, "amountOutMin", formatWei(amountOutMin))
 
 	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SwapExactSingle from %s?", fromAddress))
 	if err != nil {
@@ -366,9 +707,9 @@ func SwapExactTokensForTokens() {
 		return
 	}
 
-	_, err = swapExactTokensForTokens(tokenInAddress, tokenOutAddress, int64(amountIn), int64(amountOutMin))
+	_, err = swapExactTokensForTokens(tokenInAddress, tokenOutAddress, int64(amountIn), amountOutMin)
 	if err != nil {
-		fmt.Println("swapExactTokensForTokens error", err)
+		printCommandError("swapExactTokensForTokens error", err)
 		return
 	}
 }
@@ -399,10 +740,6 @@ func CreatePool() {
 		fmt.Println("Error parsing FEE", err)
 		return
 	}
-	if fee != 500 && fee != 3000 && fee != 10000 {
-		fmt.Println("Accepted values for FEE are 500, 3000, 10000")
-		return
-	}
 
 	v3coreFactoryContractAddr := os.Getenv("V3_CORE_FACTORY_CONTRACT_ADDRESS")
 	if common.IsHexAddress(v3coreFactoryContractAddr) == false {
@@ -418,6 +755,12 @@ func CreatePool() {
 	}
 	fromAddress = common.HexToAddress(fromAddr)
 
+	_, err = checkFeeTier(int64(fee))
+	if err != nil {
+		fmt.Println("Invalid FEE", err)
+		return
+	}
+
 	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to CreatePool from %s?", fromAddress))
 	if err != nil {
 		fmt.Println("error", err)
@@ -430,7 +773,7 @@ func CreatePool() {
 
 	_, err = createPool(tokenAaddress, tokenBaddress, int64(fee))
 	if err != nil {
-		fmt.Println("createPool error", err)
+		printCommandError("createPool error", err)
 		return
 	}
 }
@@ -461,10 +804,6 @@ func GetPool() {
 		fmt.Println("Error parsing FEE", err)
 		return
 	}
-	if fee != 500 && fee != 3000 && fee != 10000 {
-		fmt.Println("Accepted values for FEE are 500, 3000, 10000")
-		return
-	}
 
 	v3coreFactoryContractAddr := os.Getenv("V3_CORE_FACTORY_CONTRACT_ADDRESS")
 	if common.IsHexAddress(v3coreFactoryContractAddr) == false {
@@ -473,6 +812,12 @@ func GetPool() {
 	}
 	v3CoreFactoryAddress = common.HexToAddress(v3coreFactoryContractAddr)
 
+	_, err = checkFeeTier(int64(fee))
+	if err != nil {
+		fmt.Println("Invalid FEE", err)
+		return
+	}
+
 	_, err = getPool(tokenAaddress, tokenBaddress, int64(fee))
 	if err != nil {
 		fmt.Println("getPool error", err)
@@ -480,6 +825,25 @@ func GetPool() {
 	}
 }
 
+func FeeTiers() {
+	v3coreFactoryContractAddr := os.Getenv("V3_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V3_CORE_FACTORY_CONTRACT_ADDRESS", v3coreFactoryContractAddr)
+		return
+	}
+	v3CoreFactoryAddress = common.HexToAddress(v3coreFactoryContractAddr)
+
+	fees, tickSpacings, err := getFeeTiers()
+	if err != nil {
+		fmt.Println("getFeeTiers error", err)
+		return
+	}
+
+	for i, fee := range fees {
+		fmt.Println("fee", fee, "("+new(big.Rat).SetFrac64(fee, 10000).FloatString(2)+"%)", "tickSpacing", tickSpacings[i])
+	}
+}
+
 func InitializePool() {
 	if len(os.Args) < 6 {
 		printHelp()
@@ -494,8 +858,8 @@ func InitializePool() {
 	poolAddress := common.HexToAddress(poolAddr)
 
 	priceVal := os.Args[3]
-	price, err := strconv.ParseUint(priceVal, 10, 64)
-	if err != nil {
+	price, err := ParseBigRat(priceVal)
+	if err != nil || price.Sign() <= 0 {
 		fmt.Println("Error parsing PRICE_IN_TOKEN_B_PER_TOKEN_A", err)
 		return
 	}
@@ -539,15 +903,19 @@ func InitializePool() {
 		return
 	}
 
-	_, err = initializePool(poolAddress, int64(price), uint8(tokenAdecimals), uint8(tokenBdecimals))
+	_, err = initializePool(poolAddress, price, uint8(tokenAdecimals), uint8(tokenBdecimals))
 	if err != nil {
-		fmt.Println("initializePool error", err)
+		printCommandError("initializePool error", err)
 		return
 	}
 }
 
 func AddLiquidityV3() {
-	if len(os.Args) < 11 {
+	argCount := 11
+	if slippageTolerance != nil {
+		argCount = 9
+	}
+	if len(os.Args) < argCount {
 		printHelp()
 		return
 	}
@@ -572,24 +940,9 @@ func AddLiquidityV3() {
 		fmt.Println("Error parsing FEE", err)
 		return
 	}
-	if fee != 500 && fee != 3000 && fee != 10000 {
-		fmt.Println("Accepted values for FEE are 500, 3000, 10000")
-		return
-	}
-
-	tickLowerVal := os.Args[5]
-	tickLower, err := strconv.ParseInt(tickLowerVal, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing TICK_LOWER", err)
-		return
-	}
 
-	tickUpperVal := os.Args[6]
-	tickUpper, err := strconv.ParseInt(tickUpperVal, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing TICK_UPPER", err)
-		return
-	}
+	rangeLowerVal := os.Args[5]
+	rangeUpperVal := os.Args[6]
 
 	amountAval := os.Args[7]
 	amountA, err := strconv.ParseUint(amountAval, 10, 64)
@@ -605,18 +958,19 @@ func AddLiquidityV3() {
 		return
 	}
 
-	amountAminval := os.Args[9]
-	amountAmin, err := strconv.ParseUint(amountAminval, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing AMOUNT_A_MIN", err)
-		return
-	}
+	var amountAmin, amountBmin *big.Int
+	if slippageTolerance == nil {
+		amountAmin, err = parseAmountBound(os.Args[9])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_A_MIN", err)
+			return
+		}
 
-	amountBminval := os.Args[10]
-	amountBmin, err := strconv.ParseUint(amountBminval, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing AMOUNT_B_MIN", err)
-		return
+		amountBmin, err = parseAmountBound(os.Args[10])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_B_MIN", err)
+			return
+		}
 	}
 
 	nfPositionManagerAddr := os.Getenv("NONFUNGIBLE_POSITION_MANAGER_CONTRACT_ADDRESS")
@@ -633,6 +987,47 @@ func AddLiquidityV3() {
 	}
 	fromAddress = common.HexToAddress(fromAddr)
 
+	_, err = checkFeeTier(int64(fee))
+	if err != nil {
+		fmt.Println("Invalid FEE", err)
+		return
+	}
+
+	tickLower, tickUpper, err := resolveV3TickRange(tokenAaddress, tokenBaddress, int64(fee), rangeLowerVal, rangeUpperVal)
+	if err != nil {
+		fmt.Println("Error resolving RANGE_LOWER and RANGE_UPPER", err)
+		return
+	}
+
+	amountAwei := params.EtherToWei(new(big.Int).SetUint64(amountA))
+	amountBwei := params.EtherToWei(new(big.Int).SetUint64(amountB))
+	var expectedA, expectedB *big.Int
+	sqrtPriceX96, err := getV3PoolSqrtPrice(tokenAaddress, tokenBaddress, int64(fee))
+	if err != nil {
+		fmt.Println("Error calculating the deposit amounts", err)
+	} else if sqrtPriceX96 == nil {
+		fmt.Println("The pool does not exist or is not initialized, so the deposit amounts cannot be calculated. Use launchpool to create it at a price.")
+	} else {
+		expectedA, expectedB, err = getV3LiquidityAmounts(tokenAaddress, tokenBaddress, sqrtPriceX96, tickLower, tickUpper, amountAwei, amountBwei)
+		if err != nil {
+			fmt.Println("Error calculating the deposit amounts", err)
+		} else {
+			fmt.Println("Expected deposit", "amountA", formatWei(expectedA), "amountB", formatWei(expectedB))
+		}
+	}
+	if slippageTolerance != nil {
+		if expectedA == nil {
+			return
+		}
+		amountAmin = applySlippage(expectedA, slippageTolerance)
+		amountBmin = applySlippage(expectedB, slippageTolerance)
+	} else {
+		warnIfNoProtection("AMOUNT_A_MIN", amountAmin, expectedA)
+		warnIfNoProtection("AMOUNT_B_MIN", amountBmin, expectedB)
+	}
+
+	fmt.Println("AddLiquidityV3", "tokenAaddress", tokenAaddress, "tokenBaddress", tokenBaddress, "fee", fee, "tickLower", tickLower, "tickUpper", tickUpper,
+		"amountA", amountA, "amountB", amountB, "amountAmin", formatWei(amountAmin), "amountBmin", formatWei(amountBmin))
 	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to AddLiquidityV3 from %s?", fromAddress))
 	if err != nil {
 		fmt.Println("error", err)
@@ -643,32 +1038,36 @@ func AddLiquidityV3() {
 		return
 	}
 
-	_, err = addLiquidityV3(tokenAaddress, tokenBaddress, int64(fee), tickLower, tickUpper, int64(amountA), int64(amountB), int64(amountAmin), int64(amountBmin))
+	_, err = addLiquidityV3(tokenAaddress, tokenBaddress, int64(fee), int64(tickLower), int64(tickUpper), int64(amountA), int64(amountB), amountAmin, amountBmin)
 	if err != nil {
-		fmt.Println("addLiquidityV3 error", err)
+		printCommandError("addLiquidityV3 error", err)
 		return
 	}
 }
 
-func ExactInputSingle() {
-	if len(os.Args) < 7 {
+func LaunchPool() {
+	argCount := 12
+	if slippageTolerance != nil {
+		argCount = 10
+	}
+	if len(os.Args) < argCount {
 		printHelp()
 		return
 	}
 
-	tokenInaddr := os.Args[2]
-	if common.IsHexAddress(tokenInaddr) == false {
-		fmt.Println("Invalid TOKEN_IN_ADDRESS", tokenInaddr)
+	tokenAaddr := os.Args[2]
+	if common.IsHexAddress(tokenAaddr) == false {
+		fmt.Println("Invalid TOKEN_A_ADDRESS", tokenAaddr)
 		return
 	}
-	tokenInAddress := common.HexToAddress(tokenInaddr)
+	tokenAaddress := common.HexToAddress(tokenAaddr)
 
-	tokenOutaddr := os.Args[3]
-	if common.IsHexAddress(tokenOutaddr) == false {
-		fmt.Println("Invalid TOKEN_OUT_ADDRESS", tokenOutaddr)
+	tokenBaddr := os.Args[3]
+	if common.IsHexAddress(tokenBaddr) == false {
+		fmt.Println("Invalid TOKEN_B_ADDRESS", tokenBaddr)
 		return
 	}
-	tokenOutAddress := common.HexToAddress(tokenOutaddr)
+	tokenBaddress := common.HexToAddress(tokenBaddr)
 
 	feeVal := os.Args[4]
 	fee, err := strconv.ParseUint(feeVal, 10, 64)
@@ -676,31 +1075,67 @@ func ExactInputSingle() {
 		fmt.Println("Error parsing FEE", err)
 		return
 	}
-	if fee != 500 && fee != 3000 && fee != 10000 {
-		fmt.Println("Accepted values for FEE are 500, 3000, 10000")
+
+	priceVal := os.Args[5]
+	price, err := ParseBigRat(priceVal)
+	if err != nil || price.Sign() <= 0 {
+		fmt.Println("Error parsing PRICE_IN_TOKEN_B_PER_TOKEN_A", err)
 		return
 	}
 
-	amountInVal := os.Args[5]
-	amountIn, err := strconv.ParseUint(amountInVal, 10, 64)
+	minPriceVal := os.Args[6]
+	minPrice, err := ParseBigRat(minPriceVal)
+	if err != nil || minPrice.Sign() <= 0 {
+		fmt.Println("Error parsing MIN_PRICE", err)
+		return
+	}
+
+	maxPriceVal := os.Args[7]
+	maxPrice, err := ParseBigRat(maxPriceVal)
+	if err != nil || maxPrice.Sign() <= 0 {
+		fmt.Println("Error parsing MAX_PRICE", err)
+		return
+	}
+	if minPrice.Cmp(maxPrice) >= 0 {
+		fmt.Println("MIN_PRICE should be less than MAX_PRICE")
+		return
+	}
+
+	amountAval := os.Args[8]
+	amountA, err := strconv.ParseUint(amountAval, 10, 64)
 	if err != nil {
-		fmt.Println("Error parsing AMOUNT_IN", err)
+		fmt.Println("Error parsing AMOUNT_A", err)
 		return
 	}
 
-	amountOutMinVal := os.Args[6]
-	amountOutMin, err := strconv.ParseUint(amountOutMinVal, 10, 64)
+	amountBval := os.Args[9]
+	amountB, err := strconv.ParseUint(amountBval, 10, 64)
 	if err != nil {
-		fmt.Println("Error parsing AMOUNT_OUT_MIN", err)
+		fmt.Println("Error parsing AMOUNT_B", err)
 		return
 	}
 
-	v3SwapRouterContractAddr := os.Getenv("SWAP_ROUTER_CONTRACT_ADDRESS")
-	if common.IsHexAddress(v3SwapRouterContractAddr) == false {
-		fmt.Println("Invalid SWAP_ROUTER_CONTRACT_ADDRESS", v3SwapRouterContractAddr)
+	var amountAmin, amountBmin *big.Int
+	if slippageTolerance == nil {
+		amountAmin, err = parseAmountBound(os.Args[10])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_A_MIN", err)
+			return
+		}
+
+		amountBmin, err = parseAmountBound(os.Args[11])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_B_MIN", err)
+			return
+		}
+	}
+
+	nfPositionManagerAddr := os.Getenv("NONFUNGIBLE_POSITION_MANAGER_CONTRACT_ADDRESS")
+	if common.IsHexAddress(nfPositionManagerAddr) == false {
+		fmt.Println("Invalid NONFUNGIBLE_POSITION_MANAGER_CONTRACT_ADDRESS", nfPositionManagerAddr)
 		return
 	}
-	v3SwapRouterContractAddress = common.HexToAddress(v3SwapRouterContractAddr)
+	nonFungiblePositionManagerAddress = common.HexToAddress(nfPositionManagerAddr)
 
 	fromAddr := os.Getenv("FROM_ADDRESS")
 	if common.IsHexAddress(fromAddr) == false {
@@ -709,9 +1144,53 @@ func ExactInputSingle() {
 	}
 	fromAddress = common.HexToAddress(fromAddr)
 
-	fmt.Println("SwapExactSingle", "v3SwapRouterContractAddr", v3SwapRouterContractAddr, "tokenInaddr", tokenInaddr, "tokenOutAddress", tokenOutAddress, "fee", fee,
-		"amountInVal", amountInVal, "amountOutMinVal", amountOutMinVal)
-	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SwapExactSingle from %s?", fromAddress))
+	tickSpacing, err := checkFeeTier(int64(fee))
+	if err != nil {
+		fmt.Println("Invalid FEE", err)
+		return
+	}
+
+	tokenAdecimals, tokenBdecimals, err := getTokenPairDecimals(tokenAaddress, tokenBaddress)
+	if err != nil {
+		fmt.Println("Error reading the token decimals", err)
+		return
+	}
+
+	// The pool is initialized at PRICE unless it already is, in which case the position is minted at the pool's price
+	sqrtPriceX96, tickLower, tickUpper, err := getLaunchRange(tokenAaddress, tokenBaddress, price, minPrice, maxPrice, tokenAdecimals, tokenBdecimals, tickSpacing)
+	if err != nil {
+		fmt.Println("Invalid MIN_PRICE and MAX_PRICE", err)
+		return
+	}
+	poolSqrtPriceX96, err := getV3PoolSqrtPrice(tokenAaddress, tokenBaddress, int64(fee))
+	if err == nil && poolSqrtPriceX96 != nil {
+		fmt.Println("The pool is already initialized, the position is added at the pool's current price")
+		sqrtPriceX96 = poolSqrtPriceX96
+	}
+
+	amountAwei := params.EtherToWei(new(big.Int).SetUint64(amountA))
+	amountBwei := params.EtherToWei(new(big.Int).SetUint64(amountB))
+	expectedA, expectedB, err := getV3LiquidityAmounts(tokenAaddress, tokenBaddress, sqrtPriceX96, tickLower, tickUpper, amountAwei, amountBwei)
+	if err != nil {
+		fmt.Println("Error calculating the deposit amounts", err)
+		if slippageTolerance != nil {
+			return
+		}
+	} else {
+		fmt.Println("Expected deposit", "amountA", formatWei(expectedA), "amountB", formatWei(expectedB))
+	}
+	if slippageTolerance != nil {
+		amountAmin = applySlippage(expectedA, slippageTolerance)
+		amountBmin = applySlippage(expectedB, slippageTolerance)
+	} else {
+		warnIfNoProtection("AMOUNT_A_MIN", amountAmin, expectedA)
+		warnIfNoProtection("AMOUNT_B_MIN", amountBmin, expectedB)
+	}
+
+	fmt.Println("LaunchPool", "nfPositionManagerAddr", nfPositionManagerAddr, "tokenAaddress", tokenAaddress, "tokenBaddress", tokenBaddress, "fee", fee,
+		"price", price, "minPrice", minPrice, "maxPrice", maxPrice, "amountA", amountA, "amountB", amountB, "amountAmin", formatWei(amountAmin), "amountBmin", formatWei(amountBmin))
+
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to LaunchPool from %s?", fromAddress))
 	if err != nil {
 		fmt.Println("error", err)
 		return
@@ -721,21 +1200,82 @@ func ExactInputSingle() {
 		return
 	}
 
-	_, err = swapExactInputSingle(tokenInAddress, tokenOutAddress, int64(fee), int64(amountIn), int64(amountOutMin))
+	_, err = launchPool(tokenAaddress, tokenBaddress, int64(fee), price, minPrice, maxPrice, int64(amountA), int64(amountB), amountAmin, amountBmin)
 	if err != nil {
-		fmt.Println("swapExactSingle error", err)
+		printCommandError("launchPool error", err)
 		return
 	}
 }
 
-func ExactOutputSingle() {
+func V3Deposit() {
 	if len(os.Args) < 7 {
 		printHelp()
 		return
 	}
 
-	tokenInaddr := os.Args[2]
-	if common.IsHexAddress(tokenInaddr) == false {
+	poolAddr := os.Args[2]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	tickLowerVal := os.Args[3]
+	tickLower, err := strconv.ParseInt(tickLowerVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing TICK_LOWER", err)
+		return
+	}
+
+	tickUpperVal := os.Args[4]
+	tickUpper, err := strconv.ParseInt(tickUpperVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing TICK_UPPER", err)
+		return
+	}
+
+	tokenAddr := os.Args[5]
+	if common.IsHexAddress(tokenAddr) == false {
+		fmt.Println("Invalid TOKEN_ADDRESS", tokenAddr)
+		return
+	}
+	tokenAddress := common.HexToAddress(tokenAddr)
+
+	amountVal := os.Args[6]
+	amount, err := strconv.ParseUint(amountVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing AMOUNT", err)
+		return
+	}
+
+	slippagePercent := getSlippagePercent()
+	if len(os.Args) > 7 {
+		slippagePercent, err = ParseBigRat(os.Args[7])
+		if err != nil || slippagePercent.Sign() < 0 || slippagePercent.Cmp(big.NewRat(100, 1)) >= 0 {
+			fmt.Println("Error parsing SLIPPAGE_PERCENT", err)
+			return
+		}
+	}
+
+	_, _, _, err = getV3Deposit(poolAddress, tickLower, tickUpper, tokenAddress, params.EtherToWei(new(big.Int).SetUint64(amount)), slippagePercent)
+	if err != nil {
+		fmt.Println("v3deposit error", err)
+		return
+	}
+}
+
+func ExactInputSingle() {
+	argCount := 7
+	if slippageTolerance != nil {
+		argCount = 6
+	}
+	if len(os.Args) < argCount {
+		printHelp()
+		return
+	}
+
+	tokenInaddr := os.Args[2]
+	if common.IsHexAddress(tokenInaddr) == false {
 		fmt.Println("Invalid TOKEN_IN_ADDRESS", tokenInaddr)
 		return
 	}
@@ -754,8 +1294,123 @@ func ExactOutputSingle() {
 		fmt.Println("Error parsing FEE", err)
 		return
 	}
-	if fee != 500 && fee != 3000 && fee != 10000 {
-		fmt.Println("Accepted values for FEE are 500, 3000, 10000")
+
+	amountInVal := os.Args[5]
+	amountIn, err := strconv.ParseUint(amountInVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing AMOUNT_IN", err)
+		return
+	}
+
+	var amountOutMin *big.Int
+	if slippageTolerance == nil {
+		amountOutMin, err = parseAmountBound(os.Args[6])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_OUT_MIN", err)
+			return
+		}
+	}
+
+	var limitPrice *big.Rat
+	if len(os.Args) > argCount {
+		limitPrice, err = ParseBigRat(os.Args[argCount])
+		if err != nil || limitPrice.Sign() <= 0 {
+			fmt.Println("Error parsing LIMIT_PRICE", os.Args[argCount], err)
+			return
+		}
+	}
+
+	v3SwapRouterContractAddr := os.Getenv("SWAP_ROUTER_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3SwapRouterContractAddr) == false {
+		fmt.Println("Invalid SWAP_ROUTER_CONTRACT_ADDRESS", v3SwapRouterContractAddr)
+		return
+	}
+	v3SwapRouterContractAddress = common.HexToAddress(v3SwapRouterContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	_, err = checkFeeTier(int64(fee))
+	if err != nil {
+		fmt.Println("Invalid FEE", err)
+		return
+	}
+
+	var quotedAmount *big.Int
+	quoterV2ContractAddr := os.Getenv("QUOTER_V2_CONTRACT_ADDRESS")
+	if common.IsHexAddress(quoterV2ContractAddr) {
+		quoterv2ContractAddress = common.HexToAddress(quoterV2ContractAddr)
+		quote, err := getV3Quote(fmt.Sprintf("%s:%d:%s", tokenInaddr, fee, tokenOutaddr), params.EtherToWei(big.NewInt(int64(amountIn))), true, limitPrice)
+		if err != nil {
+			fmt.Println("Error quoting swap", err)
+			return
+		}
+		quotedAmount = quote.Amount
+	}
+	if slippageTolerance != nil {
+		if quotedAmount == nil {
+			fmt.Println(SLIPPAGE_FLAG, "needs a quote, set QUOTER_V2_CONTRACT_ADDRESS")
+			return
+		}
+		amountOutMin = applySlippage(quotedAmount, slippageTolerance)
+		if limitPrice != nil {
+			fmt.Println("AMOUNT_OUT_MIN is derived from the quote up to LIMIT_PRICE, the part of AMOUNT_IN the swap fills")
+		}
+	} else {
+		warnIfNoProtection("AMOUNT_OUT_MIN", amountOutMin, quotedAmount)
+	}
+
+	fmt.Println("SwapExactSingle", "v3SwapRouterContractAddr", v3SwapRouterContractAddr, "tokenInaddr", tokenInaddr, "tokenOutAddress", tokenOutAddress, "fee", fee,
+		"amountInVal", amountInVal, "amountOutMin", formatWei(amountOutMin))
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SwapExactSingle from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = swapExactInputSingle(tokenInAddress, tokenOutAddress, int64(fee), int64(amountIn), amountOutMin, limitPrice)
+	if err != nil {
+		printCommandError("swapExactSingle error", err)
+		return
+	}
+}
+
+func ExactOutputSingle() {
+	argCount := 7
+	if slippageTolerance != nil {
+		argCount = 6
+	}
+	if len(os.Args) < argCount {
+		printHelp()
+		return
+	}
+
+	tokenInaddr := os.Args[2]
+	if common.IsHexAddress(tokenInaddr) == false {
+		fmt.Println("Invalid TOKEN_IN_ADDRESS", tokenInaddr)
+		return
+	}
+	tokenInAddress := common.HexToAddress(tokenInaddr)
+
+	tokenOutaddr := os.Args[3]
+	if common.IsHexAddress(tokenOutaddr) == false {
+		fmt.Println("Invalid TOKEN_OUT_ADDRESS", tokenOutaddr)
+		return
+	}
+	tokenOutAddress := common.HexToAddress(tokenOutaddr)
+
+	feeVal := os.Args[4]
+	fee, err := strconv.ParseUint(feeVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing FEE", err)
 		return
 	}
 
@@ -766,11 +1421,22 @@ func ExactOutputSingle() {
 		return
 	}
 
-	amountInMaxVal := os.Args[6]
-	amountInMax, err := strconv.ParseUint(amountInMaxVal, 10, 64)
-	if err != nil {
-		fmt.Println("Error parsing AMOUNT_IN_MAX", err)
-		return
+	var amountInMax *big.Int
+	if slippageTolerance == nil {
+		amountInMax, err = parseAmountBound(os.Args[6])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_IN_MAX", err)
+			return
+		}
+	}
+
+	var limitPrice *big.Rat
+	if len(os.Args) > argCount {
+		limitPrice, err = ParseBigRat(os.Args[argCount])
+		if err != nil || limitPrice.Sign() <= 0 {
+			fmt.Println("Error parsing LIMIT_PRICE", os.Args[argCount], err)
+			return
+		}
 	}
 
 	v3SwapRouterContractAddr := os.Getenv("SWAP_ROUTER_CONTRACT_ADDRESS")
@@ -787,8 +1453,38 @@ func ExactOutputSingle() {
 	}
 	fromAddress = common.HexToAddress(fromAddr)
 
+	_, err = checkFeeTier(int64(fee))
+	if err != nil {
+		fmt.Println("Invalid FEE", err)
+		return
+	}
+
+	var quotedAmount *big.Int
+	quoterV2ContractAddr := os.Getenv("QUOTER_V2_CONTRACT_ADDRESS")
+	if common.IsHexAddress(quoterV2ContractAddr) {
+		quoterv2ContractAddress = common.HexToAddress(quoterV2ContractAddr)
+		quote, err := getV3Quote(fmt.Sprintf("%s:%d:%s", tokenInaddr, fee, tokenOutaddr), params.EtherToWei(big.NewInt(int64(amountOut))), false, limitPrice)
+		if err != nil {
+			fmt.Println("Error quoting swap", err)
+			return
+		}
+		quotedAmount = quote.Amount
+	}
+	if slippageTolerance != nil {
+		if quotedAmount == nil {
+			fmt.Println(SLIPPAGE_FLAG, "needs a quote, set QUOTER_V2_CONTRACT_ADDRESS")
+			return
+		}
+		amountInMax = addSlippage(quotedAmount, slippageTolerance)
+		if limitPrice != nil {
+			fmt.Println("AMOUNT_IN_MAX is derived from the quote up to LIMIT_PRICE, the part of AMOUNT_OUT the swap fills")
+		}
+	} else {
+		warnIfNoMaxProtection("AMOUNT_IN_MAX", amountInMax, quotedAmount)
+	}
+
 	fmt.Println("ExactOutputSingle", "v3SwapRouterContractAddr", v3SwapRouterContractAddr, "tokenInaddr", tokenInaddr, "tokenOutAddress", tokenOutAddress, "fee", fee,
-		"amountOutVal", amountOutVal, "amountInMaxVal", amountInMaxVal)
+		"amountOutVal", amountOutVal, "amountInMax", formatWei(amountInMax))
 	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SwapExactSingle from %s?", fromAddress))
 	if err != nil {
 		fmt.Println("error", err)
@@ -799,43 +1495,2009 @@ func ExactOutputSingle() {
 		return
 	}
 
-	_, err = swapExactOutputSingle(tokenInAddress, tokenOutAddress, int64(fee), int64(amountOut), int64(amountInMax))
+	_, err = swapExactOutputSingle(tokenInAddress, tokenOutAddress, int64(fee), int64(amountOut), amountInMax, limitPrice)
 	if err != nil {
-		fmt.Println("swapExactSingle error", err)
+		printCommandError("swapExactSingle error", err)
 		return
 	}
 }
 
-func PriceToTick() {
-	if len(os.Args) < 3 {
+func ExactInput() {
+	argCount := 5
+	if slippageTolerance != nil {
+		argCount = 4
+	}
+	if len(os.Args) < argCount {
 		printHelp()
 		return
 	}
 
-	priceVal := os.Args[2]
-	price, err := ParseBigFloat(priceVal)
+	route := os.Args[2]
+
+	amountInVal := os.Args[3]
+	amountIn, err := strconv.ParseUint(amountInVal, 10, 64)
 	if err != nil {
-		fmt.Println("Error parsing PRICE", err)
+		fmt.Println("Error parsing AMOUNT_IN", err)
 		return
 	}
 
-	tick := getTickFromPrice(price)
-	fmt.Println("Price", price, "Tick", tick)
+	var amountOutMin *big.Int
+	if slippageTolerance == nil {
+		amountOutMin, err = parseAmountBound(os.Args[4])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_OUT_MIN", err)
+			return
+		}
+	}
+
+	v3SwapRouterContractAddr := os.Getenv("SWAP_ROUTER_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3SwapRouterContractAddr) == false {
+		fmt.Println("Invalid SWAP_ROUTER_CONTRACT_ADDRESS", v3SwapRouterContractAddr)
+		return
+	}
+	v3SwapRouterContractAddress = common.HexToAddress(v3SwapRouterContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	tokens, fees, err := getV3Route(route)
+	if err != nil {
+		fmt.Println("Invalid ROUTE", err)
+		return
+	}
+
+	// QuoterV2 quotes without a balance or an approval of the router, the router simulation is the fallback
+	var amountOut *big.Int
+	quoterV2ContractAddr := os.Getenv("QUOTER_V2_CONTRACT_ADDRESS")
+	if common.IsHexAddress(quoterV2ContractAddr) {
+		quoterv2ContractAddress = common.HexToAddress(quoterV2ContractAddr)
+		quote, err := getV3Quote(route, params.EtherToWei(big.NewInt(int64(amountIn))), true, nil)
+		if err != nil {
+			fmt.Println("Error quoting route", err)
+			return
+		}
+		amountOut = quote.Amount
+	} else {
+		amountOut, err = quoteV3Route(tokens, fees, params.EtherToWei(big.NewInt(int64(amountIn))), true)
+		if err != nil {
+			fmt.Println("Error quoting route", err)
+			return
+		}
+	}
+	fmt.Println("Quoted amount out", formatWei(amountOut))
+	if slippageTolerance != nil {
+		amountOutMin = applySlippage(amountOut, slippageTolerance)
+	} else if amountOut.Cmp(amountOutMin) < 0 {
+		fmt.Println("Warning: quoted amount out is less than AMOUNT_OUT_MIN, the swap will revert")
+	} else {
+		warnIfNoProtection("AMOUNT_OUT_MIN", amountOutMin, amountOut)
+	}
+
+	fmt.Println("ExactInput", "v3SwapRouterContractAddr", v3SwapRouterContractAddr, "route", route,
+		"amountInVal", amountInVal, "amountOutMin", formatWei(amountOutMin))
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SwapExactInput from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = swapExactInput(tokens, fees, int64(amountIn), amountOutMin)
+	if err != nil {
+		printCommandError("swapExactInput error", err)
+		return
+	}
 }
 
-func TickToPrice() {
-	if len(os.Args) < 3 {
+func ExactOutput() {
+	argCount := 5
+	if slippageTolerance != nil {
+		argCount = 4
+	}
+	if len(os.Args) < argCount {
 		printHelp()
 		return
 	}
 
-	tickVal := os.Args[2]
-	tick, err := strconv.ParseInt(tickVal, 10, 64)
+	route := os.Args[2]
+
+	amountOutVal := os.Args[3]
+	amountOut, err := strconv.ParseUint(amountOutVal, 10, 64)
 	if err != nil {
-		fmt.Println("Error parsing TICK", err)
+		fmt.Println("Error parsing AMOUNT_OUT", err)
+		return
+	}
+
+	var amountInMax *big.Int
+	if slippageTolerance == nil {
+		amountInMax, err = parseAmountBound(os.Args[4])
+		if err != nil {
+			fmt.Println("Error parsing AMOUNT_IN_MAX", err)
+			return
+		}
+	}
+
+	v3SwapRouterContractAddr := os.Getenv("SWAP_ROUTER_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3SwapRouterContractAddr) == false {
+		fmt.Println("Invalid SWAP_ROUTER_CONTRACT_ADDRESS", v3SwapRouterContractAddr)
+		return
+	}
+	v3SwapRouterContractAddress = common.HexToAddress(v3SwapRouterContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	tokens, fees, err := getV3Route(route)
+	if err != nil {
+		fmt.Println("Invalid ROUTE", err)
+		return
+	}
+
+	// QuoterV2 quotes without a balance or an approval of the router, the router simulation is the fallback
+	var amountIn *big.Int
+	quoterV2ContractAddr := os.Getenv("QUOTER_V2_CONTRACT_ADDRESS")
+	if common.IsHexAddress(quoterV2ContractAddr) {
+		quoterv2ContractAddress = common.HexToAddress(quoterV2ContractAddr)
+		quote, err := getV3Quote(route, params.EtherToWei(big.NewInt(int64(amountOut))), false, nil)
+		if err != nil {
+			fmt.Println("Error quoting route", err)
+			return
+		}
+		amountIn = quote.Amount
+	} else {
+		amountIn, err = quoteV3Route(tokens, fees, params.EtherToWei(big.NewInt(int64(amountOut))), false)
+		if err != nil {
+			fmt.Println("Error quoting route", err)
+			return
+		}
+	}
+	fmt.Println("Quoted amount in", formatWei(amountIn))
+	if slippageTolerance != nil {
+		amountInMax = addSlippage(amountIn, slippageTolerance)
+	} else if amountIn.Cmp(amountInMax) > 0 {
+		fmt.Println("Warning: quoted amount in is more than AMOUNT_IN_MAX, the swap will revert")
+	} else {
+		warnIfNoMaxProtection("AMOUNT_IN_MAX", amountInMax, amountIn)
+	}
+
+	fmt.Println("ExactOutput", "v3SwapRouterContractAddr", v3SwapRouterContractAddr, "route", route,
+		"amountOutVal", amountOutVal, "amountInMax", formatWei(amountInMax))
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SwapExactOutput from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = swapExactOutput(tokens, fees, int64(amountOut), amountInMax)
+	if err != nil {
+		printCommandError("swapExactOutput error", err)
+		return
+	}
+}
+
+func QuoteV3() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	quoteType := strings.ToLower(os.Args[2])
+	if quoteType != "exactin" && quoteType != "exactout" {
+		fmt.Println("Accepted values for the quote type are exactin, exactout")
+		return
+	}
+
+	route := os.Args[3]
+
+	amountVal := os.Args[4]
+	amount, err := strconv.ParseUint(amountVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing AMOUNT", err)
+		return
+	}
+
+	quoterV2ContractAddr := os.Getenv("QUOTER_V2_CONTRACT_ADDRESS")
+	if common.IsHexAddress(quoterV2ContractAddr) == false {
+		fmt.Println("Invalid QUOTER_V2_CONTRACT_ADDRESS", quoterV2ContractAddr)
+		return
+	}
+	quoterv2ContractAddress = common.HexToAddress(quoterV2ContractAddr)
+
+	fmt.Println("QuoteV3", "quoterV2ContractAddr", quoterV2ContractAddr, "quoteType", quoteType, "route", route, "amountVal", amountVal)
+
+	_, err = getV3Quote(route, params.EtherToWei(big.NewInt(int64(amount))), quoteType == "exactin", nil)
+	if err != nil {
+		fmt.Println("getV3Quote error", err)
+		return
+	}
+}
+
+func SimulateV3() {
+	if len(os.Args) < 6 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[2]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	tokenAddr := os.Args[3]
+	if common.IsHexAddress(tokenAddr) == false {
+		fmt.Println("Invalid TOKEN_ADDRESS", tokenAddr)
+		return
+	}
+	tokenAddress := common.HexToAddress(tokenAddr)
+
+	swapType := strings.ToLower(os.Args[4])
+	if swapType != "exactin" && swapType != "exactout" {
+		fmt.Println("Accepted values for the swap type are exactin, exactout")
+		return
+	}
+
+	amountVals := strings.Split(os.Args[5], ",")
+	amounts := make([]*big.Int, 0, len(amountVals))
+	for _, amountVal := range amountVals {
+		amount, err := strconv.ParseUint(amountVal, 10, 64)
+		if err != nil || amount == 0 {
+			fmt.Println("Error parsing AMOUNT", amountVal, err)
+			return
+		}
+		amounts = append(amounts, params.EtherToWei(big.NewInt(int64(amount))))
+	}
+
+	compareWithQuoter := false
+	quoterV2ContractAddr := os.Getenv("QUOTER_V2_CONTRACT_ADDRESS")
+	if common.IsHexAddress(quoterV2ContractAddr) {
+		quoterv2ContractAddress = common.HexToAddress(quoterV2ContractAddr)
+		compareWithQuoter = true
+	}
+
+	fmt.Println("SimulateV3", "poolAddr", poolAddr, "tokenAddr", tokenAddr, "swapType", swapType, "amounts", os.Args[5])
+
+	err := simulateV3Swaps(poolAddress, tokenAddress, amounts, swapType == "exactin", compareWithQuoter)
+	if err != nil {
+		fmt.Println("simulateV3Swaps error", err)
+		return
+	}
+}
+
+func Twap() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[2]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	windowVal := os.Args[3]
+	window, err := strconv.ParseUint(windowVal, 10, 32)
+	if err != nil {
+		fmt.Println("Error parsing WINDOW_SECONDS", err)
+		return
+	}
+
+	tick, harmonicMeanLiquidity, err := getTwap(poolAddress, uint32(window))
+	if err != nil {
+		fmt.Println("getTwap error", err)
+		return
+	}
+
+	price, err := getPriceFromTick(tick)
+	if err != nil {
+		fmt.Println("getPriceFromTick error", err)
+		return
+	}
+
+	fmt.Println("Time weighted average over the last", window, "seconds")
+	fmt.Println("Tick", tick)
+	fmt.Println("Price (token1 per token0)", price.Text('g', 18))
+	fmt.Println("Price (token0 per token1)", new(big.Float).Quo(big.NewFloat(1), price).Text('g', 18))
+	fmt.Println("Harmonic mean liquidity", harmonicMeanLiquidity)
+}
+
+func IncreaseCardinality() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[2]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	cardinalityVal := os.Args[3]
+	cardinality, err := strconv.ParseUint(cardinalityVal, 10, 16)
+	if err != nil {
+		fmt.Println("Error parsing CARDINALITY", err)
+		return
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("IncreaseCardinality", "poolAddr", poolAddr, "cardinality", cardinality)
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to IncreaseObservationCardinalityNext from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = increaseObservationCardinality(poolAddress, uint16(cardinality))
+	if err != nil {
+		printCommandError("increaseObservationCardinality error", err)
+		return
+	}
+}
+
+func Admin() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	if os.Args[2] == "owner" {
+		AdminOwner()
+	} else if os.Args[2] == "enablefeeamount" {
+		AdminEnableFeeAmount()
+	} else if os.Args[2] == "setowner" {
+		AdminSetOwner()
+	} else if os.Args[2] == "feeprotocol" {
+		AdminFeeProtocol()
+	} else if os.Args[2] == "setfeeprotocol" {
+		AdminSetFeeProtocol()
+	} else if os.Args[2] == "collectprotocol" {
+		AdminCollectProtocol()
+	} else if os.Args[2] == "v2feeto" {
+		AdminV2FeeTo()
+	} else if os.Args[2] == "v2setfeeto" {
+		AdminV2SetFeeTo()
+	} else if os.Args[2] == "v2setfeetosetter" {
+		AdminV2SetFeeToSetter()
+	} else if os.Args[2] == "v2protocolfees" {
+		AdminV2ProtocolFees()
+	} else {
+		printHelp()
+	}
+}
+
+func AdminOwner() {
+	v3coreFactoryContractAddr := os.Getenv("V3_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V3_CORE_FACTORY_CONTRACT_ADDRESS", v3coreFactoryContractAddr)
+		return
+	}
+	v3CoreFactoryAddress = common.HexToAddress(v3coreFactoryContractAddr)
+
+	owner, err := getFactoryOwner()
+	if err != nil {
+		fmt.Println("getFactoryOwner error", err)
+		return
+	}
+
+	fmt.Println("owner", owner)
+}
+
+func AdminEnableFeeAmount() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	feeVal := os.Args[3]
+	fee, err := strconv.ParseInt(feeVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing FEE", err)
+		return
+	}
+	if fee < 0 || fee >= MAX_FEE_AMOUNT {
+		fmt.Println("Invalid FEE", feeVal)
+		return
+	}
+
+	tickSpacingVal := os.Args[4]
+	tickSpacing, err := strconv.ParseInt(tickSpacingVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing TICK_SPACING", err)
+		return
+	}
+
+	v3coreFactoryContractAddr := os.Getenv("V3_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V3_CORE_FACTORY_CONTRACT_ADDRESS", v3coreFactoryContractAddr)
+		return
+	}
+	v3CoreFactoryAddress = common.HexToAddress(v3coreFactoryContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("EnableFeeAmount", "fee", fee, "tickSpacing", tickSpacing)
+	fmt.Println("Fee tiers can not be disabled once enabled.")
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to EnableFeeAmount from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = enableFeeAmount(fee, tickSpacing)
+	if err != nil {
+		printCommandError("enableFeeAmount error", err)
+		return
+	}
+}
+
+func AdminSetOwner() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	newOwnerAddr := os.Args[3]
+	if common.IsHexAddress(newOwnerAddr) == false {
+		fmt.Println("Invalid NEW_OWNER_ADDRESS", newOwnerAddr)
+		return
+	}
+	newOwner := common.HexToAddress(newOwnerAddr)
+
+	v3coreFactoryContractAddr := os.Getenv("V3_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V3_CORE_FACTORY_CONTRACT_ADDRESS", v3coreFactoryContractAddr)
+		return
+	}
+	v3CoreFactoryAddress = common.HexToAddress(v3coreFactoryContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("SetOwner", "factory", v3CoreFactoryAddress, "newOwner", newOwner)
+	fmt.Println("The transfer takes effect immediately. FROM_ADDRESS will no longer be able to govern the factory and its pools.")
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SetOwner from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = setFactoryOwner(newOwner)
+	if err != nil {
+		printCommandError("setFactoryOwner error", err)
+		return
+	}
+}
+
+func AdminFeeProtocol() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[3]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	feeProtocol0, feeProtocol1, protocolFees0, protocolFees1, err := getFeeProtocol(poolAddress)
+	if err != nil {
+		fmt.Println("getFeeProtocol error", err)
+		return
+	}
+
+	fmt.Println("feeProtocol0", feeProtocol0, "feeProtocol1", feeProtocol1)
+	fmt.Println("protocolFees0 (wei)", protocolFees0, "protocolFees1 (wei)", protocolFees1)
+}
+
+func AdminSetFeeProtocol() {
+	if len(os.Args) < 6 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[3]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	feeProtocol0Val := os.Args[4]
+	feeProtocol0, err := strconv.ParseUint(feeProtocol0Val, 10, 8)
+	if err != nil {
+		fmt.Println("Error parsing FEE_PROTOCOL0", err)
+		return
+	}
+	err = validateFeeProtocol(uint8(feeProtocol0))
+	if err != nil {
+		fmt.Println("Invalid FEE_PROTOCOL0", err)
+		return
+	}
+
+	feeProtocol1Val := os.Args[5]
+	feeProtocol1, err := strconv.ParseUint(feeProtocol1Val, 10, 8)
+	if err != nil {
+		fmt.Println("Error parsing FEE_PROTOCOL1", err)
+		return
+	}
+	err = validateFeeProtocol(uint8(feeProtocol1))
+	if err != nil {
+		fmt.Println("Invalid FEE_PROTOCOL1", err)
+		return
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	currentFeeProtocol0, currentFeeProtocol1, _, _, err := getFeeProtocol(poolAddress)
+	if err != nil {
+		fmt.Println("getFeeProtocol error", err)
+		return
+	}
+
+	fmt.Println("SetFeeProtocol", "poolAddr", poolAddr, "feeProtocol0", currentFeeProtocol0, "->", feeProtocol0, "feeProtocol1", currentFeeProtocol1, "->", feeProtocol1)
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SetFeeProtocol from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = setFeeProtocol(poolAddress, uint8(feeProtocol0), uint8(feeProtocol1))
+	if err != nil {
+		printCommandError("setFeeProtocol error", err)
+		return
+	}
+}
+
+func AdminCollectProtocol() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[3]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	recipientAddr := os.Args[4]
+	if common.IsHexAddress(recipientAddr) == false {
+		fmt.Println("Invalid RECIPIENT_ADDRESS", recipientAddr)
+		return
+	}
+	recipient := common.HexToAddress(recipientAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("CollectProtocol", "poolAddr", poolAddr, "recipient", recipient)
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to CollectProtocol from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = collectProtocol(poolAddress, recipient)
+	if err != nil {
+		printCommandError("collectProtocol error", err)
+		return
+	}
+}
+
+func AdminV2FeeTo() {
+	v2coreFactoryContractAddr := os.Getenv("V2_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v2coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V2_CORE_FACTORY_CONTRACT_ADDRESS", v2coreFactoryContractAddr)
+		return
+	}
+	v2CoreFactoryAddress = common.HexToAddress(v2coreFactoryContractAddr)
+
+	feeTo, feeToSetter, err := getV2FeeTo()
+	if err != nil {
+		fmt.Println("getV2FeeTo error", err)
+		return
+	}
+
+	if feeTo.IsEqualTo(common.Address{}) {
+		fmt.Println("feeTo", feeTo, "(protocol fee is off)")
+	} else {
+		fmt.Println("feeTo", feeTo, "(protocol fee is on)")
+	}
+	fmt.Println("feeToSetter", feeToSetter)
+}
+
+func AdminV2SetFeeTo() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	var feeTo common.Address
+	feeToAddr := os.Args[3]
+	if feeToAddr != "off" {
+		if common.IsHexAddress(feeToAddr) == false {
+			fmt.Println("Invalid FEE_TO_ADDRESS", feeToAddr)
+			return
+		}
+		feeTo = common.HexToAddress(feeToAddr)
+	}
+
+	v2coreFactoryContractAddr := os.Getenv("V2_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v2coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V2_CORE_FACTORY_CONTRACT_ADDRESS", v2coreFactoryContractAddr)
+		return
+	}
+	v2CoreFactoryAddress = common.HexToAddress(v2coreFactoryContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	if feeTo.IsEqualTo(common.Address{}) {
+		fmt.Println("SetFeeTo", "feeTo", feeTo, "(turns the protocol fee off)")
+	} else {
+		fmt.Println("SetFeeTo", "feeTo", feeTo, "(turns the protocol fee on)")
+	}
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SetFeeTo from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = setV2FeeTo(feeTo)
+	if err != nil {
+		printCommandError("setV2FeeTo error", err)
+		return
+	}
+}
+
+func AdminV2SetFeeToSetter() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	feeToSetterAddr := os.Args[3]
+	if common.IsHexAddress(feeToSetterAddr) == false {
+		fmt.Println("Invalid NEW_FEE_TO_SETTER_ADDRESS", feeToSetterAddr)
+		return
+	}
+	feeToSetter := common.HexToAddress(feeToSetterAddr)
+
+	v2coreFactoryContractAddr := os.Getenv("V2_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v2coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V2_CORE_FACTORY_CONTRACT_ADDRESS", v2coreFactoryContractAddr)
+		return
+	}
+	v2CoreFactoryAddress = common.HexToAddress(v2coreFactoryContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("SetFeeToSetter", "factory", v2CoreFactoryAddress, "feeToSetter", feeToSetter)
+	fmt.Println("The handover takes effect immediately. FROM_ADDRESS will no longer be able to change the fee switch.")
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to SetFeeToSetter from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = setV2FeeToSetter(feeToSetter)
+	if err != nil {
+		printCommandError("setV2FeeToSetter error", err)
+		return
+	}
+}
+
+func AdminV2ProtocolFees() {
+	v2coreFactoryContractAddr := os.Getenv("V2_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v2coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V2_CORE_FACTORY_CONTRACT_ADDRESS", v2coreFactoryContractAddr)
+		return
+	}
+	v2CoreFactoryAddress = common.HexToAddress(v2coreFactoryContractAddr)
+
+	feeTo, fees, err := getV2ProtocolFees()
+	if err != nil {
+		fmt.Println("getV2ProtocolFees error", err)
+		return
+	}
+
+	if feeTo.IsEqualTo(common.Address{}) {
+		fmt.Println("The protocol fee is off, no LP tokens are accruing to feeTo")
+		return
+	}
+
+	fmt.Println("feeTo", feeTo)
+	for _, fee := range fees {
+		fmt.Println("pair", fee.Pair, "token0", fee.Token0, "token1", fee.Token1)
+		fmt.Println("      pending LP tokens (wei)", fee.Liquidity, "worth token0 (wei)", fee.Amount0, "token1 (wei)", fee.Amount1)
+		fmt.Println("      LP tokens held by feeTo (wei)", fee.FeeToShares)
+	}
+	fmt.Println("Pending LP tokens are estimated from kLast and the current reserves, and are minted to feeTo the next time liquidity is added to or removed from the pair.")
+}
+
+func Staker() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	v3StakerContractAddr := os.Getenv("V3_STAKER_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3StakerContractAddr) == false {
+		fmt.Println("Invalid V3_STAKER_CONTRACT_ADDRESS", v3StakerContractAddr)
+		return
+	}
+	v3StakerContractAddress = common.HexToAddress(v3StakerContractAddr)
+
+	if os.Args[2] == "createincentive" {
+		StakerCreateIncentive()
+	} else if os.Args[2] == "incentive" {
+		StakerIncentive()
+	} else if os.Args[2] == "deposit" {
+		StakerDeposit()
+	} else if os.Args[2] == "stake" {
+		StakerStake()
+	} else if os.Args[2] == "unstake" {
+		StakerUnstake()
+	} else if os.Args[2] == "rewardinfo" {
+		StakerRewardInfo()
+	} else if os.Args[2] == "rewards" {
+		StakerRewards()
+	} else if os.Args[2] == "claim" {
+		StakerClaim()
+	} else if os.Args[2] == "withdraw" {
+		StakerWithdraw()
+	} else if os.Args[2] == "endincentive" {
+		StakerEndIncentive()
+	} else {
+		printHelp()
+	}
+}
+
+func StakerCreateIncentive() {
+	if len(os.Args) < 9 {
+		printHelp()
+		return
+	}
+
+	rewardTokenAddr := os.Args[3]
+	if common.IsHexAddress(rewardTokenAddr) == false {
+		fmt.Println("Invalid REWARD_TOKEN_ADDRESS", rewardTokenAddr)
+		return
+	}
+
+	poolAddr := os.Args[4]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+
+	now, err := getLatestBlockTime()
+	if err != nil {
+		fmt.Println("getLatestBlockTime error", err)
+		return
+	}
+
+	startTime, err := resolveIncentiveTime(os.Args[5], now)
+	if err != nil {
+		fmt.Println("Error parsing START_TIME", err)
+		return
+	}
+
+	endTime, err := resolveIncentiveTime(os.Args[6], now)
+	if err != nil {
+		fmt.Println("Error parsing END_TIME", err)
+		return
+	}
+
+	err = validateIncentiveTimes(startTime, endTime, now)
+	if err != nil {
+		fmt.Println("Invalid incentive times", err)
+		return
+	}
+
+	refundeeAddr := os.Args[7]
+	if common.IsHexAddress(refundeeAddr) == false {
+		fmt.Println("Invalid REFUNDEE_ADDRESS", refundeeAddr)
+		return
+	}
+
+	rewardVal := os.Args[8]
+	reward, err := strconv.ParseInt(rewardVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing REWARD", err)
+		return
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	incentiveKey := v3staker.IUniswapV3StakerIncentiveKey{
+		RewardToken: common.HexToAddress(rewardTokenAddr),
+		Pool:        common.HexToAddress(poolAddr),
+		StartTime:   new(big.Int).SetUint64(startTime),
+		EndTime:     new(big.Int).SetUint64(endTime),
+		Refundee:    common.HexToAddress(refundeeAddr),
+	}
+
+	fmt.Println("CreateIncentive", "reward", reward, "startTime", startTime, "endTime", endTime, "duration (seconds)", endTime-startTime)
+	fmt.Println("INCENTIVE", formatIncentiveKey(incentiveKey))
+	fmt.Println("incentiveId", common.Hash(getIncentiveId(incentiveKey)))
+	fmt.Println("Note down INCENTIVE, it is needed to stake, unstake and end the incentive. REWARD_TOKEN_ADDRESS must be approved for V3_STAKER_CONTRACT_ADDRESS.")
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to CreateIncentive from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = createIncentive(incentiveKey, reward)
+	if err != nil {
+		printCommandError("createIncentive error", err)
+		return
+	}
+}
+
+func StakerIncentive() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	incentiveKey, err := parseIncentiveKey(os.Args[3])
+	if err != nil {
+		fmt.Println("Invalid INCENTIVE", err)
+		return
+	}
+
+	totalRewardUnclaimed, numberOfStakes, err := getIncentive(incentiveKey)
+	if err != nil {
+		fmt.Println("getIncentive error", err)
+		return
+	}
+
+	fmt.Println("incentiveId", common.Hash(getIncentiveId(incentiveKey)))
+	fmt.Println("totalRewardUnclaimed", formatWei(totalRewardUnclaimed), "numberOfStakes", numberOfStakes)
+}
+
+func StakerDeposit() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	tokenId, ok := new(big.Int).SetString(os.Args[3], 10)
+	if ok == false || tokenId.Sign() < 0 {
+		fmt.Println("Invalid TOKEN_ID", os.Args[3])
+		return
+	}
+
+	var incentiveKey *v3staker.IUniswapV3StakerIncentiveKey
+	if len(os.Args) > 4 {
+		parsed, err := parseIncentiveKey(os.Args[4])
+		if err != nil {
+			fmt.Println("Invalid INCENTIVE", err)
+			return
+		}
+		incentiveKey = &parsed
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("Deposit", "tokenId", tokenId, "staker", v3StakerContractAddress)
+	if incentiveKey != nil {
+		fmt.Println("The position will also be staked in the incentive", formatIncentiveKey(*incentiveKey))
+	}
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to Deposit from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = depositPosition(tokenId, incentiveKey)
+	if err != nil {
+		printCommandError("depositPosition error", err)
+		return
+	}
+}
+
+func StakerStake() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	tokenId, ok := new(big.Int).SetString(os.Args[3], 10)
+	if ok == false || tokenId.Sign() < 0 {
+		fmt.Println("Invalid TOKEN_ID", os.Args[3])
+		return
+	}
+
+	incentiveKey, err := parseIncentiveKey(os.Args[4])
+	if err != nil {
+		fmt.Println("Invalid INCENTIVE", err)
+		return
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("StakeToken", "tokenId", tokenId, "incentive", formatIncentiveKey(incentiveKey))
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to StakeToken from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = stakePosition(incentiveKey, tokenId)
+	if err != nil {
+		printCommandError("stakePosition error", err)
+		return
+	}
+}
+
+func StakerUnstake() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	tokenId, ok := new(big.Int).SetString(os.Args[3], 10)
+	if ok == false || tokenId.Sign() < 0 {
+		fmt.Println("Invalid TOKEN_ID", os.Args[3])
+		return
+	}
+
+	incentiveKey, err := parseIncentiveKey(os.Args[4])
+	if err != nil {
+		fmt.Println("Invalid INCENTIVE", err)
+		return
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	reward, _, err := getStakeRewardInfo(incentiveKey, tokenId)
+	if err != nil {
+		fmt.Println("getStakeRewardInfo error", err)
+		return
+	}
+
+	fmt.Println("UnstakeToken", "tokenId", tokenId, "incentive", formatIncentiveKey(incentiveKey), "reward", formatWei(reward))
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to UnstakeToken from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = unstakePosition(incentiveKey, tokenId)
+	if err != nil {
+		printCommandError("unstakePosition error", err)
+		return
+	}
+}
+
+func StakerRewardInfo() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	tokenId, ok := new(big.Int).SetString(os.Args[3], 10)
+	if ok == false || tokenId.Sign() < 0 {
+		fmt.Println("Invalid TOKEN_ID", os.Args[3])
+		return
+	}
+
+	incentiveKey, err := parseIncentiveKey(os.Args[4])
+	if err != nil {
+		fmt.Println("Invalid INCENTIVE", err)
+		return
+	}
+
+	reward, secondsInsideX128, err := getStakeRewardInfo(incentiveKey, tokenId)
+	if err != nil {
+		fmt.Println("getStakeRewardInfo error", err)
+		return
+	}
+
+	fmt.Println("reward", formatWei(reward), "secondsInsideX128", secondsInsideX128)
+	fmt.Println("The reward becomes claimable once the position is unstaked")
+}
+
+func StakerRewards() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	rewardTokenAddr := os.Args[3]
+	if common.IsHexAddress(rewardTokenAddr) == false {
+		fmt.Println("Invalid REWARD_TOKEN_ADDRESS", rewardTokenAddr)
+		return
+	}
+	rewardToken := common.HexToAddress(rewardTokenAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	rewards, err := getStakerRewards(rewardToken, fromAddress)
+	if err != nil {
+		fmt.Println("getStakerRewards error", err)
+		return
+	}
+
+	fmt.Println("rewards", formatWei(rewards))
+}
+
+func StakerClaim() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	rewardTokenAddr := os.Args[3]
+	if common.IsHexAddress(rewardTokenAddr) == false {
+		fmt.Println("Invalid REWARD_TOKEN_ADDRESS", rewardTokenAddr)
+		return
+	}
+	rewardToken := common.HexToAddress(rewardTokenAddr)
+
+	recipientAddr := os.Args[4]
+	if common.IsHexAddress(recipientAddr) == false {
+		fmt.Println("Invalid RECIPIENT_ADDRESS", recipientAddr)
+		return
+	}
+	recipient := common.HexToAddress(recipientAddr)
+
+	amount := big.NewInt(0)
+	if len(os.Args) > 5 {
+		amountVal, err := strconv.ParseInt(os.Args[5], 10, 64)
+		if err != nil || amountVal < 0 {
+			fmt.Println("Error parsing AMOUNT", err)
+			return
+		}
+		amount = params.EtherToWei(big.NewInt(amountVal))
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	if amount.Sign() == 0 {
+		fmt.Println("ClaimReward", "rewardToken", rewardToken, "recipient", recipient, "amount", "all")
+	} else {
+		fmt.Println("ClaimReward", "rewardToken", rewardToken, "recipient", recipient, "amount", formatWei(amount))
+	}
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to ClaimReward from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = claimStakerReward(rewardToken, recipient, amount)
+	if err != nil {
+		printCommandError("claimStakerReward error", err)
+		return
+	}
+}
+
+func StakerWithdraw() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	tokenId, ok := new(big.Int).SetString(os.Args[3], 10)
+	if ok == false || tokenId.Sign() < 0 {
+		fmt.Println("Invalid TOKEN_ID", os.Args[3])
+		return
+	}
+
+	recipientAddr := os.Args[4]
+	if common.IsHexAddress(recipientAddr) == false {
+		fmt.Println("Invalid RECIPIENT_ADDRESS", recipientAddr)
+		return
+	}
+	recipient := common.HexToAddress(recipientAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("WithdrawToken", "tokenId", tokenId, "recipient", recipient)
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to WithdrawToken from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = withdrawPosition(tokenId, recipient)
+	if err != nil {
+		printCommandError("withdrawPosition error", err)
+		return
+	}
+}
+
+func StakerEndIncentive() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	incentiveKey, err := parseIncentiveKey(os.Args[3])
+	if err != nil {
+		fmt.Println("Invalid INCENTIVE", err)
+		return
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("EndIncentive", "incentive", formatIncentiveKey(incentiveKey), "refundee", incentiveKey.Refundee)
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to EndIncentive from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = endIncentive(incentiveKey)
+	if err != nil {
+		printCommandError("endIncentive error", err)
+		return
+	}
+}
+
+func Migrate() {
+	if len(os.Args) < 7 {
+		printHelp()
+		return
+	}
+
+	pairAddr := os.Args[2]
+	if common.IsHexAddress(pairAddr) == false {
+		fmt.Println("Invalid PAIR_ADDRESS", pairAddr)
+		return
+	}
+	pairAddress := common.HexToAddress(pairAddr)
+
+	percentVal := os.Args[3]
+	percent, err := strconv.ParseInt(percentVal, 10, 64)
+	if err != nil || percent <= 0 || percent > 100 {
+		fmt.Println("Error parsing PERCENT, it should be between 1 and 100", err)
+		return
+	}
+
+	feeVal := os.Args[4]
+	fee, err := strconv.ParseInt(feeVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing FEE", err)
+		return
+	}
+
+	rangeLower := os.Args[5]
+	rangeUpper := os.Args[6]
+
+	slippagePercent := getSlippagePercent()
+	if len(os.Args) > 7 {
+		slippagePercent, err = ParseBigRat(os.Args[7])
+		if err != nil || slippagePercent.Sign() < 0 || slippagePercent.Cmp(big.NewRat(100, 1)) >= 0 {
+			fmt.Println("Error parsing SLIPPAGE_PERCENT", err)
+			return
+		}
+	}
+
+	v3MigratorContractAddr := os.Getenv("V3_MIGRATOR_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3MigratorContractAddr) == false {
+		fmt.Println("Invalid V3_MIGRATOR_CONTRACT_ADDRESS", v3MigratorContractAddr)
+		return
+	}
+	v3MigratorContractAddress = common.HexToAddress(v3MigratorContractAddr)
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	migration, err := getV2Migration(pairAddress, percent, fee, rangeLower, rangeUpper, slippagePercent)
+	if err != nil {
+		fmt.Println("getV2Migration error", err)
+		return
+	}
+
+	fmt.Println("Migrate", "pairAddr", pairAddr, "percent", percent, "fee", fee, "slippagePercent", slippagePercent.FloatString(2))
+	fmt.Println("The LP tokens will be approved for V3_MIGRATOR_CONTRACT_ADDRESS first if needed. Unused tokens are refunded to FROM_ADDRESS.")
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to Migrate from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = migrateV2ToV3(migration)
+	if err != nil {
+		printCommandError("migrateV2ToV3 error", err)
+		return
+	}
+}
+
+func Flash() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	if os.Args[2] == "fee" {
+		FlashFee()
+	} else if os.Args[2] == "simulate" {
+		FlashSimulate()
+	} else if os.Args[2] == "events" {
+		FlashEvents()
+	} else {
+		printHelp()
+	}
+}
+
+// parseFlashAmounts parses AMOUNT0 and AMOUNT1 starting at os.Args[index]
+func parseFlashAmounts(index int) (*big.Int, *big.Int, error) {
+	amount0, err := strconv.ParseInt(os.Args[index], 10, 64)
+	if err != nil || amount0 < 0 {
+		return nil, nil, fmt.Errorf("error parsing AMOUNT0 %s", os.Args[index])
+	}
+	amount1, err := strconv.ParseInt(os.Args[index+1], 10, 64)
+	if err != nil || amount1 < 0 {
+		return nil, nil, fmt.Errorf("error parsing AMOUNT1 %s", os.Args[index+1])
+	}
+	return params.EtherToWei(big.NewInt(amount0)), params.EtherToWei(big.NewInt(amount1)), nil
+}
+
+func printFlashFees(fees *flashFees, amount0 *big.Int, amount1 *big.Int) {
+	if fees.IsV3 {
+		fmt.Println("v3 pool flash loan", "fee", fees.Fee, "token0", fees.Token0, "token1", fees.Token1)
+	} else {
+		fmt.Println("v2 pair flash swap", "fee", "0.3%", "token0", fees.Token0, "token1", fees.Token1)
+	}
+	fmt.Println("      token0 borrow", formatWei(amount0), "fee", formatWei(fees.Fee0), "repay", formatWei(new(big.Int).Add(amount0, fees.Fee0)), "available", formatWei(fees.Available0))
+	fmt.Println("      token1 borrow", formatWei(amount1), "fee", formatWei(fees.Fee1), "repay", formatWei(new(big.Int).Add(amount1, fees.Fee1)), "available", formatWei(fees.Available1))
+	if fees.IsV3 == false {
+		fmt.Println("The repayment is in the borrowed token. A v2 flash swap can also be repaid in the other token at the pair price.")
+	}
+}
+
+func FlashFee() {
+	if len(os.Args) < 6 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[3]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	amount0, amount1, err := parseFlashAmounts(4)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fees, err := getFlashFees(poolAddress, amount0, amount1)
+	if err != nil {
+		fmt.Println("getFlashFees error", err)
+		return
+	}
+
+	printFlashFees(fees, amount0, amount1)
+}
+
+func FlashSimulate() {
+	if len(os.Args) < 7 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[3]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	receiverAddr := os.Args[4]
+	if common.IsHexAddress(receiverAddr) == false {
+		fmt.Println("Invalid RECEIVER_ADDRESS", receiverAddr)
+		return
+	}
+	receiver := common.HexToAddress(receiverAddr)
+
+	amount0, amount1, err := parseFlashAmounts(5)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	dataVal := ""
+	if len(os.Args) > 7 {
+		dataVal = os.Args[7]
+	}
+	data, err := encodeFlashData(dataVal)
+	if err != nil {
+		fmt.Println("Invalid DATA", err)
+		return
+	}
+
+	fees, err := getFlashFees(poolAddress, amount0, amount1)
+	if err != nil {
+		fmt.Println("getFlashFees error", err)
+		return
+	}
+
+	printFlashFees(fees, amount0, amount1)
+	fmt.Println("receiver", receiver, "data", fmt.Sprintf("0x%x", data))
+
+	err = simulateFlash(poolAddress, receiver, amount0, amount1, data)
+	if err != nil {
+		fmt.Println("simulateFlash error, the receiver did not repay the flash borrow", err)
+		return
+	}
+
+	fmt.Println("The simulation succeeded, the receiver repaid the amounts borrowed plus the fees")
+}
+
+func FlashEvents() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	poolAddr := os.Args[3]
+	if common.IsHexAddress(poolAddr) == false {
+		fmt.Println("Invalid POOL_ADDRESS", poolAddr)
+		return
+	}
+	poolAddress := common.HexToAddress(poolAddr)
+
+	fromBlock := uint64(0)
+	if len(os.Args) > 4 {
+		var err error
+		fromBlock, err = strconv.ParseUint(os.Args[4], 10, 64)
+		if err != nil {
+			fmt.Println("Error parsing FROM_BLOCK", err)
+			return
+		}
+	}
+
+	events, err := getFlashEvents(poolAddress, fromBlock)
+	if err != nil {
+		fmt.Println("getFlashEvents error", err)
+		return
+	}
+
+	paid0 := big.NewInt(0)
+	paid1 := big.NewInt(0)
+	for _, event := range events {
+		fmt.Println("block", event.Raw.BlockNumber, "tx", event.Raw.TxHash, "sender", event.Sender, "recipient", event.Recipient)
+		fmt.Println("      amount0", formatWei(event.Amount0), "amount1", formatWei(event.Amount1), "paid0", formatWei(event.Paid0), "paid1", formatWei(event.Paid1))
+		paid0.Add(paid0, event.Paid0)
+		paid1.Add(paid1, event.Paid1)
+	}
+
+	fmt.Println("flash loans", len(events), "total paid0", formatWei(paid0), "total paid1", formatWei(paid1))
+}
+
+func Deploy() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	artifactsDir := os.Args[2]
+	info, err := os.Stat(artifactsDir)
+	if err != nil || info.IsDir() == false {
+		fmt.Println("Invalid ARTIFACTS_DIR", artifactsDir)
+		return
+	}
+
+	stateFile := DEFAULT_DEPLOYMENT_STATE_FILE
+	if len(os.Args) > 3 {
+		stateFile = os.Args[3]
+	}
+
+	addressBookFile := DEFAULT_ADDRESS_BOOK_FILE
+	if len(os.Args) > 4 {
+		addressBookFile = os.Args[4]
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	fmt.Println("Deploy", "artifactsDir", artifactsDir, "stateFile", stateFile, "addressBookFile", addressBookFile)
+	for _, settings := range getDeploymentOrder() {
+		fmt.Println("      ", settings.ContractName, "gas", settings.Gas)
+	}
+	fmt.Println("Contracts already deployed according to the state file are not deployed again.")
+
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to deploy the QuantumSwap contracts from %s?", fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	addresses, err := deployStack(artifactsDir, stateFile)
+	if err != nil {
+		printCommandError("deployStack error", err)
+		return
+	}
+
+	err = writeAddressBook(addressBookFile, addresses)
+	if err != nil {
+		fmt.Println("writeAddressBook error", err)
+		return
+	}
+
+	for _, settings := range getDeploymentOrder() {
+		fmt.Println(settings.AddressEnv, addresses[settings.ContractId])
+	}
+	fmt.Println("The addresses were written to", addressBookFile, ". Set", ADDRESS_BOOK_ENV, "to it to use them in the other commands.")
+}
+
+func VerifyDeployment() {
+	artifactsDir := ""
+	if len(os.Args) > 2 {
+		artifactsDir = os.Args[2]
+		info, err := os.Stat(artifactsDir)
+		if err != nil || info.IsDir() == false {
+			fmt.Println("Invalid ARTIFACTS_DIR", artifactsDir)
+			return
+		}
+	}
+
+	checks, err := verifyDeployment(artifactsDir)
+	if err != nil {
+		fmt.Println("verifyDeployment error", err)
+		return
+	}
+
+	failed := 0
+	skipped := 0
+	for _, check := range checks {
+		if check.Skipped {
+			skipped++
+			fmt.Println("SKIPPED", check.Name, check.Detail)
+		} else if check.Ok {
+			fmt.Println("OK     ", check.Name, check.Detail)
+		} else {
+			failed++
+			fmt.Println("FAILED ", check.Name, check.Detail)
+		}
+	}
+
+	fmt.Println("checks", len(checks), "failed", failed, "skipped", skipped)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func ComputePair() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	tokenAaddr := os.Args[2]
+	if common.IsHexAddress(tokenAaddr) == false {
+		fmt.Println("Invalid TOKEN_A_ADDRESS", tokenAaddr)
+		return
+	}
+	tokenAaddress := common.HexToAddress(tokenAaddr)
+
+	tokenBaddr := os.Args[3]
+	if common.IsHexAddress(tokenBaddr) == false {
+		fmt.Println("Invalid TOKEN_B_ADDRESS", tokenBaddr)
+		return
+	}
+	tokenBaddress := common.HexToAddress(tokenBaddr)
+
+	check := len(os.Args) > 4 && os.Args[4] == "check"
+
+	v2coreFactoryContractAddr := os.Getenv("V2_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v2coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V2_CORE_FACTORY_CONTRACT_ADDRESS", v2coreFactoryContractAddr)
+		return
+	}
+	v2CoreFactoryAddress = common.HexToAddress(v2coreFactoryContractAddr)
+
+	initCodeHash := getV2PairInitCodeHash()
+	pairAddress, err := computeV2PairAddress(v2CoreFactoryAddress, tokenAaddress, tokenBaddress, initCodeHash)
+	if err != nil {
+		fmt.Println("computeV2PairAddress error", err)
+		return
+	}
+
+	token0, token1 := sortTokens(tokenAaddress, tokenBaddress)
+	fmt.Println("token0", token0, "token1", token1, "INIT_CODE_HASH", initCodeHash)
+	fmt.Println("computed v2 pairAddress", pairAddress)
+
+	if check == false {
+		return
+	}
+
+	factoryInitCodeHash, err := getV2FactoryInitCodeHash()
+	if err != nil {
+		fmt.Println("getV2FactoryInitCodeHash error", err)
+		return
+	}
+	if factoryInitCodeHash != initCodeHash {
+		fmt.Println("MISMATCH: the INIT_CODE_HASH of the factory is", factoryInitCodeHash)
+	}
+
+	factoryPairAddress, err := getPair(tokenAaddress, tokenBaddress)
+	if err != nil {
+		fmt.Println("getPair error", err)
+		return
+	}
+	if factoryPairAddress.IsEqualTo(common.Address{}) {
+		fmt.Println("The pair has not been created yet")
+	} else if factoryPairAddress.IsEqualTo(pairAddress) {
+		fmt.Println("The computed address matches the factory")
+	} else {
+		fmt.Println("MISMATCH: the factory returned", factoryPairAddress)
+	}
+}
+
+func ComputePool() {
+	if len(os.Args) < 5 {
+		printHelp()
+		return
+	}
+
+	tokenAaddr := os.Args[2]
+	if common.IsHexAddress(tokenAaddr) == false {
+		fmt.Println("Invalid TOKEN_A_ADDRESS", tokenAaddr)
+		return
+	}
+	tokenAaddress := common.HexToAddress(tokenAaddr)
+
+	tokenBaddr := os.Args[3]
+	if common.IsHexAddress(tokenBaddr) == false {
+		fmt.Println("Invalid TOKEN_B_ADDRESS", tokenBaddr)
+		return
+	}
+	tokenBaddress := common.HexToAddress(tokenBaddr)
+
+	feeVal := os.Args[4]
+	fee, err := strconv.ParseInt(feeVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing FEE", err)
+		return
+	}
+
+	check := len(os.Args) > 5 && os.Args[5] == "check"
+
+	v3coreFactoryContractAddr := os.Getenv("V3_CORE_FACTORY_CONTRACT_ADDRESS")
+	if common.IsHexAddress(v3coreFactoryContractAddr) == false {
+		fmt.Println("Invalid V3_CORE_FACTORY_CONTRACT_ADDRESS", v3coreFactoryContractAddr)
+		return
+	}
+	v3CoreFactoryAddress = common.HexToAddress(v3coreFactoryContractAddr)
+
+	initCodeHash := getV3PoolInitCodeHash()
+	poolAddress, err := computeV3PoolAddress(v3CoreFactoryAddress, tokenAaddress, tokenBaddress, fee, initCodeHash)
+	if err != nil {
+		fmt.Println("computeV3PoolAddress error", err)
+		return
+	}
+
+	token0, token1 := sortTokens(tokenAaddress, tokenBaddress)
+	fmt.Println("token0", token0, "token1", token1, "fee", fee, "POOL_INIT_CODE_HASH", initCodeHash)
+	fmt.Println("computed v3 poolAddress", poolAddress)
+
+	if check == false {
+		return
+	}
+
+	factoryPoolAddress, err := getPool(tokenAaddress, tokenBaddress, fee)
+	if err != nil {
+		fmt.Println("getPool error", err)
+		return
+	}
+	if factoryPoolAddress.IsEqualTo(common.Address{}) {
+		fmt.Println("The pool has not been created yet")
+	} else if factoryPoolAddress.IsEqualTo(poolAddress) {
+		fmt.Println("The computed address matches the factory")
+	} else {
+		fmt.Println("MISMATCH: the factory returned", factoryPoolAddress)
+	}
+}
+
+func Nonce() {
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	if len(os.Args) > 2 && os.Args[2] == "reset" {
+		err := resetNonces(fromAddress)
+		if err != nil {
+			fmt.Println("resetNonces error", err)
+			return
+		}
+		fmt.Println("The nonces issued to", fromAddress, "were reset, the next nonce is taken from the node")
+		return
+	}
+
+	pendingNonce, nextNonce, inFlight, gaps, err := getNonceStatus(fromAddress)
+	if err != nil {
+		fmt.Println("getNonceStatus error", err)
+		return
+	}
+
+	fmt.Println("account", fromAddress, "node pending nonce", pendingNonce, "next local nonce", nextNonce)
+	fmt.Println("issued and not yet confirmed", inFlight)
+	if len(gaps) > 0 {
+		fmt.Println("gaps", gaps, ": these transactions were dropped or never sent, the transactions after them wait until they are filled.")
+		fmt.Println("The next transaction sent with quantumswap-cli fills the first gap.")
+	}
+}
+
+func ReplaceTransaction(cancel bool) {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	txHashVal := os.Args[2]
+	if len(common.FromHex(txHashVal)) != common.HashLength {
+		fmt.Println("Invalid TX_HASH", txHashVal)
+		return
+	}
+	txHash := common.HexToHash(txHashVal)
+
+	bumpPercent := int64(MIN_REPLACEMENT_BUMP_PERCENT)
+	if len(os.Args) > 3 {
+		var err error
+		bumpPercent, err = strconv.ParseInt(os.Args[3], 10, 64)
+		if err != nil || bumpPercent < MIN_REPLACEMENT_BUMP_PERCENT {
+			fmt.Println("Error parsing BUMP_PERCENT, it should be at least", MIN_REPLACEMENT_BUMP_PERCENT, err)
+			return
+		}
+	}
+
+	fromAddr := os.Getenv("FROM_ADDRESS")
+	if common.IsHexAddress(fromAddr) == false {
+		fmt.Println("Invalid FROM_ADDRESS", fromAddr)
+		return
+	}
+	fromAddress = common.HexToAddress(fromAddr)
+
+	action := "speed up"
+	if cancel {
+		action = "cancel"
+	}
+	fmt.Println("ReplaceTransaction", "txHash", txHash, "action", action, "bumpPercent", bumpPercent)
+
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to %s the transaction from %s?", action, fromAddress))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	_, err = replaceTransaction(txHash, cancel, bumpPercent)
+	if err != nil {
+		printCommandError("replaceTransaction error", err)
+		return
+	}
+}
+
+func Sign() {
+	if len(os.Args) < 4 {
+		printHelp()
+		return
+	}
+
+	unsignedFile := os.Args[2]
+	signedFile := os.Args[3]
+
+	keyFile := os.Getenv("DP_KEY_FILE")
+	if len(keyFile) == 0 {
+		fmt.Println("DP_KEY_FILE is not set")
+		return
+	}
+
+	unsignedTx, _, err := readUnsignedTransaction(unsignedFile)
+	if err != nil {
+		fmt.Println("readUnsignedTransaction error", err)
+		return
+	}
+
+	fmt.Println("Sign", "chainId", unsignedTx.ChainId, "from", unsignedTx.From, "nonce", unsignedTx.Nonce, "gas", unsignedTx.Gas, "gasPrice", unsignedTx.GasPrice)
+	if unsignedTx.To == nil {
+		fmt.Println("      contract deployment", "value", unsignedTx.Value)
+	} else {
+		fmt.Println("      to", unsignedTx.To, "value", unsignedTx.Value)
+	}
+	fmt.Println("      data", unsignedTx.Data)
+
+	ethConfirm, err := prompt.Stdin.PromptConfirm(fmt.Sprintf("Do you want to sign this transaction from %s?", unsignedTx.From))
+	if err != nil {
+		fmt.Println("error", err)
+		return
+	}
+	if ethConfirm != true {
+		fmt.Println("confirmation not made")
+		return
+	}
+
+	password := os.Getenv("DP_ACC_PWD")
+	if len(password) == 0 {
+		password, err = prompt.Stdin.PromptPassword("Enter the wallet password : ")
+		if err != nil {
+			fmt.Println("error", err)
+			return
+		}
+	}
+
+	signedTx, err := signTransactionFile(unsignedFile, signedFile, keyFile, password)
+	if err != nil {
+		fmt.Println("signTransactionFile error", err)
+		return
+	}
+
+	fmt.Println("The signed transaction was written to", signedFile, "transaction hash", signedTx.Hash())
+}
+
+func Broadcast() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	receipt, err := broadcastTransactionFile(os.Args[2])
+	if err != nil {
+		fmt.Println("broadcastTransactionFile error", err)
+		return
+	}
+
+	fmt.Println("The transaction was mined in block", receipt.BlockNumber, "gas used", receipt.GasUsed)
+}
+
+func Decode() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	input := common.FromHex(os.Args[2])
+	if len(input) == common.HashLength {
+		err := decodeTransaction(common.BytesToHash(input))
+		if err != nil {
+			fmt.Println("decodeTransaction error", err)
+		}
+		return
+	}
+	if len(input) < SELECTOR_LENGTH {
+		fmt.Println("Invalid TX_HASH or CALLDATA", os.Args[2])
+		return
+	}
+
+	err := decodeInput(input, nil)
+	if err != nil {
+		fmt.Println("decodeInput error", err)
+		return
+	}
+}
+
+func PriceToTick() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	priceVal := os.Args[2]
+	price, err := ParseBigRat(priceVal)
+	if err != nil || price.Sign() <= 0 {
+		fmt.Println("Error parsing PRICE", err)
+		return
+	}
+
+	tick := getTickFromPrice(price)
+	if tick == MIN_TICK || tick == MAX_TICK {
+		fmt.Println("Price is at or beyond the supported range, tick clamped to", tick)
+	}
+
+	sqrtPriceX96, err := getSqrtRatioAtTick(tick)
+	if err != nil {
+		fmt.Println("Error calculating sqrtPriceX96", err)
+		return
+	}
+
+	fmt.Println("Price", price.FloatString(18), "Tick", tick, "TickPrice", getPriceFromSqrtPriceX96(sqrtPriceX96).Text('g', 40), "SqrtPriceX96", sqrtPriceX96)
+}
+
+func TickToPrice() {
+	if len(os.Args) < 3 {
+		printHelp()
+		return
+	}
+
+	tickVal := os.Args[2]
+	tick, err := strconv.ParseInt(tickVal, 10, 64)
+	if err != nil {
+		fmt.Println("Error parsing TICK", err)
+		return
+	}
+	if tick < MIN_TICK || tick > MAX_TICK {
+		fmt.Println("Tick is out of range, clamped to", clampTick(tick))
+		tick = int64(clampTick(tick))
+	}
+
+	sqrtPriceX96, err := getSqrtRatioAtTick(int32(tick))
+	if err != nil {
+		fmt.Println("Error calculating sqrtPriceX96", err)
 		return
 	}
 
-	price := getPriceFromTick(int32(tick))
-	fmt.Println("Tick", tick, "Price", price)
+	fmt.Println("Tick", tick, "Price", getPriceFromSqrtPriceX96(sqrtPriceX96).Text('g', 40), "SqrtPriceX96", sqrtPriceX96)
 }
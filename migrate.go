@@ -0,0 +1,374 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"quantumswap-cli/contracts/core"
+	"quantumswap-cli/contracts/pairv2"
+	"quantumswap-cli/contracts/v3migrator"
+	"quantumswap-cli/contracts/v3pool"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Migration of v2 liquidity to a v3 position with V3Migrator. The migrator pulls the LP tokens, burns them, mints a v3
+// position with the tokens received and refunds whatever the position did not use to the sender.
+
+type v2Migration struct {
+	Pair           common.Address
+	Token0         common.Address
+	Token1         common.Address
+	Fee            int64
+	Liquidity      *big.Int // v2 LP tokens to burn
+	TickLower      int32
+	TickUpper      int32
+	Amount0Min     *big.Int
+	Amount1Min     *big.Int
+	InitializePool bool     // the v3 pool does not exist or is not initialized, it is initialized at the v2 price
+	SqrtPriceX96   *big.Int // the price the v3 pool is (or will be) at
+}
+
+// getV2Migration plans the migration of percent of fromAddress's LP tokens of the pair into the v3 pool of the pair's tokens and fee,
+// between the range bounds (in the forms accepted by addliquidityv3, with token A being token0 of the pair). It prints the
+// expected amounts and refunds
+func getV2Migration(pairAddress common.Address, percent int64, fee int64, lowerBound string, upperBound string,
+	slippagePercent *big.Rat) (*v2Migration, error) {
+	if percent <= 0 || percent > 100 {
+		return nil, errors.New("percent should be between 1 and 100")
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := v3migrator.NewV3migrator(v3MigratorContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	pair, err := pairv2.NewPairv2(pairAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	migration := &v2Migration{Pair: pairAddress, Fee: fee}
+
+	migration.Token0, err = pair.Token0(nil)
+	if err != nil {
+		return nil, err
+	}
+	migration.Token1, err = pair.Token1(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := pair.BalanceOf(nil, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	migration.Liquidity = new(big.Int).Div(new(big.Int).Mul(balance, big.NewInt(percent)), big.NewInt(100))
+	if migration.Liquidity.Sign() == 0 {
+		return nil, errors.New("FROM_ADDRESS has no LP tokens of the pair to migrate")
+	}
+
+	reserves, err := pair.GetReserves(nil)
+	if err != nil {
+		return nil, err
+	}
+	if reserves.Reserve0.Sign() == 0 || reserves.Reserve1.Sign() == 0 {
+		return nil, errors.New("pair has no reserves")
+	}
+	totalSupply, err := pair.TotalSupply(nil)
+	if err != nil {
+		return nil, err
+	}
+	// the amounts the burn returns, not counting the protocol fee that may be minted first
+	amount0 := new(big.Int).Div(new(big.Int).Mul(migration.Liquidity, reserves.Reserve0), totalSupply)
+	amount1 := new(big.Int).Div(new(big.Int).Mul(migration.Liquidity, reserves.Reserve1), totalSupply)
+
+	factoryAddress, err := migrator.Factory(nil)
+	if err != nil {
+		return nil, err
+	}
+	factory, err := core.NewCore(factoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	tickSpacing, err := factory.FeeAmountTickSpacing(nil, big.NewInt(fee))
+	if err != nil {
+		return nil, err
+	}
+	if tickSpacing.Sign() == 0 {
+		return nil, fmt.Errorf("fee tier %d is not enabled in factory %s", fee, factoryAddress)
+	}
+
+	v2Price := new(big.Rat).SetFrac(reserves.Reserve1, reserves.Reserve0)
+	migration.SqrtPriceX96 = getSqrtPriceX96FromPrice(v2Price)
+	poolAddress, err := factory.GetPool(nil, migration.Token0, migration.Token1, big.NewInt(fee))
+	if err != nil {
+		return nil, err
+	}
+	if poolAddress.IsEqualTo(common.Address{}) {
+		migration.InitializePool = true
+	} else {
+		pool, err := v3pool.NewV3pool(poolAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		slot0, err := pool.Slot0(nil)
+		if err != nil {
+			return nil, err
+		}
+		if slot0.SqrtPriceX96.Sign() == 0 {
+			migration.InitializePool = true
+		} else {
+			migration.SqrtPriceX96 = slot0.SqrtPriceX96
+		}
+	}
+
+	token0Decimals, err := getTokenDecimals(migration.Token0, client)
+	if err != nil {
+		return nil, err
+	}
+	token1Decimals, err := getTokenDecimals(migration.Token1, client)
+	if err != nil {
+		return nil, err
+	}
+
+	rawPrice := new(big.Rat).SetFrac(new(big.Int).Mul(migration.SqrtPriceX96, migration.SqrtPriceX96), Q192)
+	currentPrice := getRawPrice(rawPrice, token1Decimals, token0Decimals)
+
+	tickLower, err := resolveTickBound(lowerBound, currentPrice, true, token0Decimals, token1Decimals)
+	if err != nil {
+		return nil, err
+	}
+	tickUpper, err := resolveTickBound(upperBound, currentPrice, true, token0Decimals, token1Decimals)
+	if err != nil {
+		return nil, err
+	}
+	migration.TickLower, migration.TickUpper = alignTickRange(tickLower, tickUpper, int32(tickSpacing.Int64()))
+
+	sqrtRatioLowerX96, err := getSqrtRatioAtTick(migration.TickLower)
+	if err != nil {
+		return nil, err
+	}
+	sqrtRatioUpperX96, err := getSqrtRatioAtTick(migration.TickUpper)
+	if err != nil {
+		return nil, err
+	}
+	liquidityV3, err := getLiquidityForAmounts(migration.SqrtPriceX96, sqrtRatioLowerX96, sqrtRatioUpperX96, amount0, amount1)
+	if err != nil {
+		return nil, err
+	}
+	used0, used1, err := getAmountsForLiquidity(migration.SqrtPriceX96, sqrtRatioLowerX96, sqrtRatioUpperX96, liquidityV3)
+	if err != nil {
+		return nil, err
+	}
+	migration.Amount0Min = applySlippage(used0, slippagePercent)
+	migration.Amount1Min = applySlippage(used1, slippagePercent)
+
+	v2HumanPrice := getRawPrice(v2Price, token1Decimals, token0Decimals)
+	fmt.Println("v2 pair", pairAddress, "token0", migration.Token0, "token1", migration.Token1)
+	fmt.Println("LP tokens to migrate", formatWei(migration.Liquidity), "of", formatWei(balance))
+	fmt.Println("v2 price (token1 per token0)", v2HumanPrice.FloatString(18))
+	if migration.InitializePool {
+		fmt.Println("v3 pool", poolAddress, "is not initialized, it will be initialized at the v2 price")
+	} else {
+		fmt.Println("v3 pool", poolAddress, "price (token1 per token0)", currentPrice.FloatString(18))
+	}
+	fmt.Println("tickLower", migration.TickLower, "tickUpper", migration.TickUpper)
+	fmt.Println("Expected amounts from v2: amount0", formatWei(amount0), "amount1", formatWei(amount1))
+	fmt.Println("Expected amounts into v3: amount0", formatWei(used0), "amount1", formatWei(used1), "liquidity", liquidityV3)
+	fmt.Println("Expected refunds: amount0", formatWei(new(big.Int).Sub(amount0, used0)), "amount1", formatWei(new(big.Int).Sub(amount1, used1)))
+	fmt.Println("amount0Min", formatWei(migration.Amount0Min), "amount1Min", formatWei(migration.Amount1Min))
+	fmt.Println()
+
+	return migration, nil
+}
+
+// approveV2Liquidity approves the migrator to pull the LP tokens, if the current allowance is not enough, and waits for the approval to be mined.
+// The nonce of txnOpts is released when the approval is not sent
+func approveV2Liquidity(client *ethclient.Client, txnOpts *bind.TransactOpts, migration *v2Migration) error {
+	pair, err := pairv2.NewPairv2(migration.Pair, client)
+	if err != nil {
+		releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+		return err
+	}
+
+	allowance, err := pair.Allowance(nil, fromAddress, v3MigratorContractAddress)
+	if err != nil {
+		releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+		return err
+	}
+	if allowance.Cmp(migration.Liquidity) >= 0 {
+		return nil
+	}
+
+	if calldataOnly {
+		// the approval cannot be mined before the migration is printed, so it is printed first, to be executed first
+		parsed, err := pairv2.Pairv2MetaData.GetAbi()
+		if err != nil {
+			return err
+		}
+		data, err := parsed.Pack("approve", v3MigratorContractAddress, migration.Liquidity)
+		if err != nil {
+			return err
+		}
+		printCalldata(types.NewTransaction(0, migration.Pair, big.NewInt(0), 0, nil, data))
+		return nil
+	}
+
+	tx, err := pair.Approve(txnOpts, v3MigratorContractAddress, migration.Liquidity)
+	if err != nil {
+		releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+		return err
+	}
+
+	fmt.Println("Approving the LP tokens for the migrator. The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println("Waiting for the approval to be mined...")
+	receipt, err := bind.WaitMined(context.Background(), client, tx)
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return errors.New("approve transaction failed")
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return err
+	}
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	return nil
+}
+
+func migrateV2ToV3(migration *v2Migration) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	err = approveV2Liquidity(client, txnOpts, migration)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3migrator.NewV3migrator(v3MigratorContractAddress, client)
+	if err != nil {
+		releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+		return nil, err
+	}
+
+	var migrateParams v3migrator.IV3MigratorMigrateParams
+	migrateParams.Pair = migration.Pair
+	migrateParams.LiquidityToMigrate = migration.Liquidity
+	// all the tokens received from the burn are offered to the v3 position, the migrator refunds what the position does not use
+	migrateParams.PercentageToMigrate = 100
+	migrateParams.Token0 = migration.Token0
+	migrateParams.Token1 = migration.Token1
+	migrateParams.Fee = big.NewInt(migration.Fee)
+	migrateParams.TickLower = big.NewInt(int64(migration.TickLower))
+	migrateParams.TickUpper = big.NewInt(int64(migration.TickUpper))
+	migrateParams.Amount0Min = migration.Amount0Min
+	migrateParams.Amount1Min = migration.Amount1Min
+	migrateParams.Recipient = fromAddress
+	migrateParams.Deadline, err = getDeadline()
+	if err != nil {
+		releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+		return nil, err
+	}
+	migrateParams.RefundAsETH = false
+
+	var tx *types.Transaction
+	if migration.InitializePool {
+		parsed, err := v3migrator.V3migratorMetaData.GetAbi()
+		if err != nil {
+			releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+			return nil, err
+		}
+
+		createData, err := parsed.Pack("createAndInitializePoolIfNecessary", migration.Token0, migration.Token1, big.NewInt(migration.Fee), migration.SqrtPriceX96)
+		if err != nil {
+			releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+			return nil, err
+		}
+
+		migrateData, err := parsed.Pack("migrate", migrateParams)
+		if err != nil {
+			releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+			return nil, err
+		}
+
+		tx, err = contract.Multicall(txnOpts, [][]byte{createData, migrateData})
+		if err != nil {
+			releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+			return nil, err
+		}
+	} else {
+		tx, err = contract.Migrate(txnOpts, migrateParams)
+		if err != nil {
+			releaseNonce(fromAddress, txnOpts.Nonce.Uint64())
+			return nil, err
+		}
+	}
+
+	fmt.Println("Your request to migrate v2 liquidity to v3 has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	err = waitForDeadline(client, tx, migrateParams.Deadline)
+	if err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Nonce manager. Nonces issued to transactions are recorded per chain and account in a state file shared by every
+// quantumswap-cli process, so that back-to-back transactions, or transactions sent from two processes, do not reuse a nonce
+// the node has not seen yet. The state file is protected by a lock file.
+
+const NONCE_FILE_ENV = "DP_NONCE_FILE"
+const DEFAULT_NONCE_FILE = "quantumswap-nonces.json"
+
+// A nonce issued this long ago that the node still does not know was dropped or never sent, and is issued again
+const NONCE_GAP_SECONDS = 120
+
+// A lock file older than this was left by a process that stopped while holding it
+const NONCE_LOCK_STALE_SECONDS = 30
+
+// Longer than NONCE_LOCK_STALE_SECONDS, so that a lock left by a process that just stopped becomes stale and is taken over
+// before the wait times out
+const NONCE_LOCK_TIMEOUT_SECONDS = 45
+
+type accountNonces struct {
+	Next     uint64           `json:"next"`
+	Issued   map[uint64]int64 `json:"issued"`             // nonce to the unix time it was issued, until it is confirmed
+	Exported map[uint64]int64 `json:"exported,omitempty"` // issued nonces written to an --unsigned file, never gaps since signing may take any time
+}
+
+type nonceState struct {
+	Accounts map[string]*accountNonces `json:"accounts"`
+}
+
+func getNonceFile() string {
+	nonceFile := os.Getenv(NONCE_FILE_ENV)
+	if len(nonceFile) > 0 {
+		return nonceFile
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return DEFAULT_NONCE_FILE
+	}
+	return filepath.Join(homeDir, ".quantumswap-cli", DEFAULT_NONCE_FILE)
+}
+
+func getNonceAccountKey(chainId int64, account common.Address) string {
+	return fmt.Sprintf("%d:%s", chainId, account.Hex())
+}
+
+// lockNonceFile creates the lock file of the state file, waiting for other processes to release it, and returns the function that releases it
+func lockNonceFile(nonceFile string) (func(), error) {
+	lockFile := nonceFile + ".lock"
+	owner := fmt.Sprintf("%d:%d", os.Getpid(), time.Now().UnixNano())
+	deadline := time.Now().Add(NONCE_LOCK_TIMEOUT_SECONDS * time.Second)
+	for {
+		file, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprint(file, owner)
+			file.Close()
+			return func() { removeNonceLock(lockFile, owner, time.Time{}) }, nil
+		}
+		if os.IsExist(err) == false {
+			return nil, err
+		}
+
+		info, statErr := os.Stat(lockFile)
+		if statErr == nil && time.Since(info.ModTime()) > NONCE_LOCK_STALE_SECONDS*time.Second {
+			staleOwner, readErr := ioutil.ReadFile(lockFile)
+			if readErr == nil && removeNonceLock(lockFile, string(staleOwner), info.ModTime()) {
+				fmt.Println("Removed the stale nonce lock file", lockFile)
+			}
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for the nonce lock file %s", lockFile)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// removeNonceLock removes the lock file only if it still belongs to owner, and when modTime is set, was not written since.
+// Another process may remove a stale lock and create its own between the check and the removal, so the lock file is first
+// renamed, which only one process can do, and put back if it turns out to be a different lock
+func removeNonceLock(lockFile string, owner string, modTime time.Time) bool {
+	takenFile := fmt.Sprintf("%s.%d", lockFile, os.Getpid())
+	err := os.Rename(lockFile, takenFile)
+	if err != nil {
+		return false
+	}
+
+	content, err := ioutil.ReadFile(takenFile)
+	sameLock := err == nil && string(content) == owner
+	if sameLock && modTime.IsZero() == false {
+		info, err := os.Stat(takenFile)
+		sameLock = err == nil && info.ModTime().Equal(modTime)
+	}
+	if sameLock == false {
+		// os.Link fails if a new lock file was created meanwhile, which then is the lock
+		os.Link(takenFile, lockFile)
+	}
+	os.Remove(takenFile)
+	return sameLock
+}
+
+func readNonceState(nonceFile string) (*nonceState, error) {
+	state := &nonceState{Accounts: make(map[string]*accountNonces)}
+
+	data, err := ioutil.ReadFile(nonceFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the nonce file %s: %w", nonceFile, err)
+	}
+	if state.Accounts == nil {
+		state.Accounts = make(map[string]*accountNonces)
+	}
+	return state, nil
+}
+
+func writeNonceState(nonceFile string, state *nonceState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpFile := nonceFile + ".tmp"
+	err = ioutil.WriteFile(tmpFile, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, nonceFile)
+}
+
+// reconcileNonces drops the nonces the node has confirmed and catches up with transactions sent without the nonce manager.
+// It returns the nonces between the node's pending nonce and the next local nonce that are not in flight: those
+// transactions were dropped or never sent, and the transactions with higher nonces wait for them. An exported nonce is
+// in flight until the node confirms it or the nonces are reset
+func reconcileNonces(nonces *accountNonces, confirmedNonce uint64, pendingNonce uint64, now int64) []uint64 {
+	for nonce := range nonces.Issued {
+		if nonce < confirmedNonce {
+			delete(nonces.Issued, nonce)
+		}
+	}
+	for nonce := range nonces.Exported {
+		if nonce < confirmedNonce {
+			delete(nonces.Exported, nonce)
+		}
+	}
+	if nonces.Next < pendingNonce {
+		nonces.Next = pendingNonce
+	}
+
+	gaps := make([]uint64, 0)
+	for nonce := pendingNonce; nonce < nonces.Next; nonce++ {
+		if _, exported := nonces.Exported[nonce]; exported {
+			continue
+		}
+		issuedAt, ok := nonces.Issued[nonce]
+		if ok == false || now-issuedAt > NONCE_GAP_SECONDS {
+			gaps = append(gaps, nonce)
+		}
+	}
+	return gaps
+}
+
+func getAccountNonces(client *ethclient.Client, state *nonceState, chainId int64, account common.Address) (*accountNonces, []uint64, error) {
+	confirmedNonce, err := client.NonceAt(context.Background(), account, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	pendingNonce, err := client.PendingNonceAt(context.Background(), account)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accountKey := getNonceAccountKey(chainId, account)
+	nonces, ok := state.Accounts[accountKey]
+	if ok == false {
+		nonces = &accountNonces{Next: pendingNonce, Issued: make(map[uint64]int64)}
+		state.Accounts[accountKey] = nonces
+	}
+	if nonces.Issued == nil {
+		nonces.Issued = make(map[uint64]int64)
+	}
+
+	gaps := reconcileNonces(nonces, confirmedNonce, pendingNonce, time.Now().Unix())
+	return nonces, gaps, nil
+}
+
+// getNonce issues the nonce of the next transaction of account. A gap left by a dropped transaction is filled first
+func getNonce(client *ethclient.Client, account common.Address) (uint64, error) {
+	// calldata is sent by another account, usually a multisig, with its own nonce
+	if calldataOnly {
+		return 0, nil
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		return 0, err
+	}
+
+	nonceFile := getNonceFile()
+	err = os.MkdirAll(filepath.Dir(nonceFile), 0755)
+	if err != nil {
+		return 0, err
+	}
+
+	unlock, err := lockNonceFile(nonceFile)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	state, err := readNonceState(nonceFile)
+	if err != nil {
+		return 0, err
+	}
+
+	nonces, gaps, err := getAccountNonces(client, state, chainId, account)
+	if err != nil {
+		return 0, err
+	}
+
+	var nonce uint64
+	if len(gaps) > 0 {
+		nonce = gaps[0]
+		fmt.Println("Nonce gap detected at", gaps, ": the transactions were dropped or never sent. Reusing nonce", nonce)
+	} else {
+		nonce = nonces.Next
+		nonces.Next++
+	}
+	nonces.Issued[nonce] = time.Now().Unix()
+
+	err = writeNonceState(nonceFile, state)
+	if err != nil {
+		return 0, err
+	}
+
+	return nonce, nil
+}
+
+// releaseNonce gives back a nonce issued by getNonce whose transaction was not sent, so that the next transaction takes it
+// instead of waiting NONCE_GAP_SECONDS for it to be detected as a gap. Errors are printed, the caller is already failing
+func releaseNonce(account common.Address, nonce uint64) {
+	if calldataOnly {
+		return
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		fmt.Println("Error releasing nonce", nonce, err)
+		return
+	}
+
+	nonceFile := getNonceFile()
+	unlock, err := lockNonceFile(nonceFile)
+	if err != nil {
+		fmt.Println("Error releasing nonce", nonce, err)
+		return
+	}
+	defer unlock()
+
+	state, err := readNonceState(nonceFile)
+	if err != nil {
+		fmt.Println("Error releasing nonce", nonce, err)
+		return
+	}
+
+	nonces, ok := state.Accounts[getNonceAccountKey(chainId, account)]
+	if ok == false {
+		return
+	}
+	// the transaction of an exported nonce may still be signed and broadcast
+	if _, exported := nonces.Exported[nonce]; exported {
+		return
+	}
+	delete(nonces.Issued, nonce)
+	// a nonce below the next one stays a gap, which the next getNonce fills since it is no longer in flight
+	if nonces.Next == nonce+1 {
+		nonces.Next = nonce
+	}
+
+	err = writeNonceState(nonceFile, state)
+	if err != nil {
+		fmt.Println("Error releasing nonce", nonce, err)
+	}
+}
+
+// markNonceExported records that the transaction of a nonce issued by getNonce was written to the --unsigned file. It is not
+// issued again when signing takes longer than NONCE_GAP_SECONDS, only once the node confirms it or the nonces are reset
+func markNonceExported(account common.Address, nonce uint64) error {
+	chainId, err := getChainId()
+	if err != nil {
+		return err
+	}
+
+	nonceFile := getNonceFile()
+	unlock, err := lockNonceFile(nonceFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := readNonceState(nonceFile)
+	if err != nil {
+		return err
+	}
+
+	nonces, ok := state.Accounts[getNonceAccountKey(chainId, account)]
+	if ok == false {
+		// the nonce was not issued by getNonce, such as the nonce of a replaced transaction
+		return nil
+	}
+	if nonces.Exported == nil {
+		nonces.Exported = make(map[uint64]int64)
+	}
+	nonces.Exported[nonce] = time.Now().Unix()
+
+	return writeNonceState(nonceFile, state)
+}
+
+// getNonceStatus reconciles the nonces of account with the node without issuing one
+func getNonceStatus(account common.Address) (uint64, uint64, []uint64, []uint64, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	pendingNonce, err := client.PendingNonceAt(context.Background(), account)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	nonceFile := getNonceFile()
+	unlock, err := lockNonceFile(nonceFile)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+	defer unlock()
+
+	state, err := readNonceState(nonceFile)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	nonces, gaps, err := getAccountNonces(client, state, chainId, account)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	inFlight := make([]uint64, 0, len(nonces.Issued))
+	for nonce := range nonces.Issued {
+		if nonce >= pendingNonce {
+			inFlight = append(inFlight, nonce)
+		}
+	}
+	sort.Slice(inFlight, func(i, j int) bool { return inFlight[i] < inFlight[j] })
+
+	err = writeNonceState(nonceFile, state)
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	return pendingNonce, nonces.Next, inFlight, gaps, nil
+}
+
+// resetNonces forgets the nonces issued to account, the next nonce is taken from the node again
+func resetNonces(account common.Address) error {
+	chainId, err := getChainId()
+	if err != nil {
+		return err
+	}
+
+	nonceFile := getNonceFile()
+	unlock, err := lockNonceFile(nonceFile)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := readNonceState(nonceFile)
+	if err != nil {
+		return err
+	}
+
+	accountKey := getNonceAccountKey(chainId, account)
+	_, ok := state.Accounts[accountKey]
+	if ok == false {
+		return errors.New("no nonces were issued to the account")
+	}
+	delete(state.Accounts, accountKey)
+
+	return writeNonceState(nonceFile, state)
+}
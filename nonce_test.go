@@ -0,0 +1,156 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReconcileNonces(t *testing.T) {
+	const now = 1700000000
+
+	tests := []struct {
+		name           string
+		next           uint64
+		issued         map[uint64]int64
+		confirmedNonce uint64
+		pendingNonce   uint64
+		wantNext       uint64
+		wantIssued     []uint64
+		wantGaps       []uint64
+	}{
+		{"nothing in flight", 5, map[uint64]int64{}, 5, 5,
+			5, []uint64{}, []uint64{}},
+		{"confirmed nonces are dropped", 6, map[uint64]int64{3: now, 4: now, 5: now}, 5, 6,
+			6, []uint64{5}, []uint64{}},
+		{"catches up with transactions sent without the nonce manager", 3, map[uint64]int64{}, 7, 7,
+			7, []uint64{}, []uint64{}},
+		{"catches up with pending transactions sent without the nonce manager", 3, map[uint64]int64{2: now}, 2, 7,
+			7, []uint64{2}, []uint64{}},
+		{"in flight transactions are not gaps", 8, map[uint64]int64{5: now - 10, 6: now - 10, 7: now - 10}, 5, 5,
+			8, []uint64{5, 6, 7}, []uint64{}},
+		{"a nonce issued too long ago is a gap", 8, map[uint64]int64{5: now - 10, 6: now - NONCE_GAP_SECONDS - 1, 7: now - 10}, 5, 5,
+			8, []uint64{5, 6, 7}, []uint64{6}},
+		{"a nonce issued exactly NONCE_GAP_SECONDS ago is still in flight", 6, map[uint64]int64{5: now - NONCE_GAP_SECONDS}, 5, 5,
+			6, []uint64{5}, []uint64{}},
+		{"a released nonce is a gap at once", 8, map[uint64]int64{5: now, 7: now}, 5, 5,
+			8, []uint64{5, 7}, []uint64{6}},
+		{"every nonce from the pending nonce is a gap after a reset of the node pool", 7, map[uint64]int64{}, 5, 5,
+			7, []uint64{}, []uint64{5, 6}},
+		{"nonces below the pending nonce are not gaps", 9, map[uint64]int64{6: now - NONCE_GAP_SECONDS - 1, 8: now - NONCE_GAP_SECONDS - 1}, 6, 7,
+			9, []uint64{6, 8}, []uint64{7, 8}},
+	}
+
+	for _, test := range tests {
+		nonces := &accountNonces{Next: test.next, Issued: test.issued}
+		gaps := reconcileNonces(nonces, test.confirmedNonce, test.pendingNonce, now)
+
+		if nonces.Next != test.wantNext {
+			t.Errorf("%s: next %d, want %d", test.name, nonces.Next, test.wantNext)
+		}
+		if reflect.DeepEqual(gaps, test.wantGaps) == false {
+			t.Errorf("%s: gaps %v, want %v", test.name, gaps, test.wantGaps)
+		}
+		if len(nonces.Issued) != len(test.wantIssued) {
+			t.Errorf("%s: issued %v, want %v", test.name, nonces.Issued, test.wantIssued)
+			continue
+		}
+		for _, nonce := range test.wantIssued {
+			if _, ok := nonces.Issued[nonce]; ok == false {
+				t.Errorf("%s: issued %v, want %v", test.name, nonces.Issued, test.wantIssued)
+				break
+			}
+		}
+	}
+}
+
+func TestReconcileExportedNonces(t *testing.T) {
+	const now = 1700000000
+
+	// nonce 5 was exported long ago and is still being signed, nonce 6 was issued as long ago and dropped
+	nonces := &accountNonces{
+		Next:     8,
+		Issued:   map[uint64]int64{4: now - 1000, 5: now - 1000, 6: now - 1000, 7: now},
+		Exported: map[uint64]int64{4: now - 1000, 5: now - 1000},
+	}
+	gaps := reconcileNonces(nonces, 5, 5, now)
+
+	if reflect.DeepEqual(gaps, []uint64{6}) == false {
+		t.Errorf("gaps %v, want [6]", gaps)
+	}
+	if _, ok := nonces.Exported[4]; ok {
+		t.Errorf("the confirmed exported nonce 4 was kept")
+	}
+	if _, ok := nonces.Exported[5]; ok == false {
+		t.Errorf("the exported nonce 5 was dropped before it was confirmed")
+	}
+}
+
+func TestLockNonceFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	nonceFile := filepath.Join(dir, DEFAULT_NONCE_FILE)
+	lockFile := nonceFile + ".lock"
+
+	unlock, err := lockNonceFile(nonceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(lockFile); err != nil {
+		t.Fatalf("lock file not created: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(lockFile); os.IsNotExist(err) == false {
+		t.Fatalf("lock file not removed: %v", err)
+	}
+
+	if NONCE_LOCK_TIMEOUT_SECONDS <= NONCE_LOCK_STALE_SECONDS {
+		t.Fatal("the wait for the lock times out before a lock left by a stopped process is stale")
+	}
+
+	// a stale lock is taken over
+	err = ioutil.WriteFile(lockFile, []byte("1:1"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	staleTime := time.Now().Add(-2 * NONCE_LOCK_STALE_SECONDS * time.Second)
+	err = os.Chtimes(lockFile, staleTime, staleTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = lockNonceFile(nonceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// releasing a lock that was taken over by another process leaves the new lock in place
+	err = ioutil.WriteFile(lockFile, []byte("2:2"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	content, err := ioutil.ReadFile(lockFile)
+	if err != nil || string(content) != "2:2" {
+		t.Fatalf("the lock of the other process was removed: %q %v", content, err)
+	}
+
+	// a stale lock that was refreshed after it was checked is not removed
+	if removeNonceLock(lockFile, "2:2", staleTime) {
+		t.Fatal("removed a lock written after the stale check")
+	}
+	if _, err := os.Stat(lockFile); err != nil {
+		t.Fatalf("lock file removed: %v", err)
+	}
+	if removeNonceLock(lockFile, "3:3", time.Time{}) {
+		t.Fatal("removed the lock of another owner")
+	}
+	if removeNonceLock(lockFile, "2:2", time.Time{}) == false {
+		t.Fatal("did not remove the lock of its owner")
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/common/hexutil"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto/cryptobase"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto/signaturealgorithm"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Offline signing. With --unsigned FILE, a write command runs on the online machine without a key file and writes the
+// transaction it would send to FILE instead of signing it. sign signs that file on the offline machine, and broadcast sends
+// the signed transaction from the online machine.
+
+// Set by --unsigned
+var unsignedTxFile string
+
+// Set by --calldata. The transaction is printed as the target, value and input for a multisig or governance proposal.
+// migrate prints its approval and the migration in the order to execute them, deploy does not accept --calldata
+var calldataOnly bool
+
+// The number of calls printed by printCalldata
+var printedCalls int
+
+type unsignedTransaction struct {
+	ChainId  int64           `json:"chainId"`
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"` // nil for a contract deployment
+	Nonce    uint64          `json:"nonce"`
+	Gas      uint64          `json:"gas"`
+	GasPrice string          `json:"gasPrice"`
+	Value    string          `json:"value"`
+	Data     string          `json:"data"`
+}
+
+// getSigningKey loads the key of FROM_ADDRESS, or returns no key when the transaction is exported unsigned or as calldata
+func getSigningKey() (*signaturealgorithm.PrivateKey, error) {
+	if len(unsignedTxFile) > 0 || calldataOnly {
+		return nil, nil
+	}
+	return GetKey(fromAddress.Hex())
+}
+
+// getSigningAddress returns the address of key, or FROM_ADDRESS when there is no key
+func getSigningAddress(key *signaturealgorithm.PrivateKey) (common.Address, error) {
+	if key == nil {
+		return fromAddress, nil
+	}
+	return cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+}
+
+// errTransactionExported is returned by the binding once the transaction was written to the --unsigned file or printed as
+// calldata instead of being sent. The command stops there, which is not a failure
+var errTransactionExported = errors.New("the transaction was exported instead of sent")
+
+// printCommandError prints the error of a command, unless the command stopped because its transaction was exported
+func printCommandError(message string, err error) {
+	if errors.Is(err, errTransactionExported) {
+		return
+	}
+	fmt.Println(message, err)
+}
+
+// newTransactor returns the transact options of key. Without a key, the transaction is written to the --unsigned file, or
+// printed as calldata, when the binding would sign it, and the binding returns errTransactionExported
+func newTransactor(key *signaturealgorithm.PrivateKey, chainId *big.Int) (*bind.TransactOpts, error) {
+	if key != nil {
+		return bind.NewKeyedTransactorWithChainID(key, chainId)
+	}
+
+	txnOpts := &bind.TransactOpts{From: fromAddress}
+	if calldataOnly {
+		txnOpts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			printCalldata(tx)
+			return nil, errTransactionExported
+		}
+		return txnOpts, nil
+	}
+
+	txnOpts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		err := exportUnsignedTransaction(unsignedTxFile, chainId.Int64(), address, tx)
+		if err != nil {
+			return nil, err
+		}
+		err = markNonceExported(address, tx.Nonce())
+		if err != nil {
+			fmt.Println("Error recording the exported nonce", tx.Nonce(), err)
+		}
+		fmt.Println("The unsigned transaction was written to", unsignedTxFile, ". Sign it with quantumswap-cli sign and send it with quantumswap-cli broadcast.")
+		fmt.Println("Commands that send more than one transaction stop at the first one; run the command again once it is mined.")
+		fmt.Println("Its nonce", tx.Nonce(), "is held until the transaction is mined; if it will not be sent, run quantumswap-cli nonce reset.")
+		return nil, errTransactionExported
+	}
+	return txnOpts, nil
+}
+
+func printCalldata(tx *types.Transaction) {
+	printedCalls++
+	fmt.Println("call", printedCalls)
+	if tx.To() == nil {
+		fmt.Println("target", "(contract deployment)")
+	} else {
+		fmt.Println("target", tx.To().Hex())
+	}
+	fmt.Println("value", tx.Value())
+	fmt.Println("input", hexutil.Encode(tx.Data()))
+	fmt.Println()
+
+	if tx.To() == nil {
+		return
+	}
+	err := decodeInput(tx.Data(), tx.To())
+	if err != nil {
+		fmt.Println("Could not decode the input", err)
+	}
+}
+
+func exportUnsignedTransaction(file string, chainId int64, from common.Address, tx *types.Transaction) error {
+	unsignedTx := unsignedTransaction{
+		ChainId:  chainId,
+		From:     from,
+		To:       tx.To(),
+		Nonce:    tx.Nonce(),
+		Gas:      tx.Gas(),
+		GasPrice: tx.GasPrice().String(),
+		Value:    tx.Value().String(),
+		Data:     hexutil.Encode(tx.Data()),
+	}
+
+	data, err := json.MarshalIndent(unsignedTx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+func readUnsignedTransaction(file string) (*unsignedTransaction, *types.Transaction, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unsignedTx := &unsignedTransaction{}
+	err = json.Unmarshal(data, unsignedTx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not parse the unsigned transaction %s: %w", file, err)
+	}
+
+	gasPrice, ok := new(big.Int).SetString(unsignedTx.GasPrice, 10)
+	if ok == false {
+		return nil, nil, fmt.Errorf("invalid gasPrice %s", unsignedTx.GasPrice)
+	}
+	value, ok := new(big.Int).SetString(unsignedTx.Value, 10)
+	if ok == false {
+		return nil, nil, fmt.Errorf("invalid value %s", unsignedTx.Value)
+	}
+	txData, err := hexutil.Decode(unsignedTx.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid data: %w", err)
+	}
+
+	var tx *types.Transaction
+	if unsignedTx.To == nil {
+		tx = types.NewContractCreation(unsignedTx.Nonce, value, unsignedTx.Gas, gasPrice, txData)
+	} else {
+		tx = types.NewTransaction(unsignedTx.Nonce, *unsignedTx.To, value, unsignedTx.Gas, gasPrice, txData)
+	}
+
+	return unsignedTx, tx, nil
+}
+
+// signTransactionFile signs an unsigned transaction with the key file, without connecting to a node, and writes the raw
+// signed transaction as hex
+func signTransactionFile(unsignedFile string, signedFile string, keyFile string, password string) (*types.Transaction, error) {
+	unsignedTx, tx, err := readUnsignedTransaction(unsignedFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := GetKeyFromFile(keyFile, password)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if address.IsEqualTo(unsignedTx.From) == false {
+		return nil, fmt.Errorf("the key file is for %s, but the transaction is from %s", address, unsignedTx.From)
+	}
+
+	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(unsignedTx.ChainId))
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := txnOpts.Signer(address, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTx, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ioutil.WriteFile(signedFile, []byte(hexutil.Encode(rawTx)), 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return signedTx, nil
+}
+
+// broadcastTransactionFile sends a raw signed transaction and waits for its receipt
+func broadcastTransactionFile(signedFile string) (*types.Receipt, error) {
+	data, err := ioutil.ReadFile(signedFile)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTx, err := hexutil.Decode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signed transaction: %w", err)
+	}
+
+	tx := new(types.Transaction)
+	err = tx.UnmarshalBinary(rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.SendTransaction(context.Background(), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println("Waiting for the transaction to be mined...")
+
+	receipt, err := bind.WaitMined(context.Background(), client, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, getFailedTransactionError(client, tx, receipt)
+	}
+
+	return receipt, nil
+}
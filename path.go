@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+)
+
+// Encoding of the packed swap path used by the v3 router: token, fee, token, fee, ..., token
+// where each token takes common.AddressLength bytes (32 bytes on QuantumCoin) and each fee takes 3 bytes.
+
+const PATH_FEE_SIZE = 3
+
+// WRAPPED_Q_ROUTE_ALIAS can be used in a route in place of the wrapped Q token address
+const WRAPPED_Q_ROUTE_ALIAS = "WQ"
+
+// parseRoute parses a route such as TOKEN_A:3000:WQ:500:TOKEN_B into its tokens and fees
+func parseRoute(route string, wqAddress common.Address) ([]common.Address, []int64, error) {
+	parts := strings.Split(route, ":")
+	if len(parts) < 3 || len(parts)%2 == 0 {
+		return nil, nil, errors.New("route should be in the form TOKEN:FEE:TOKEN[:FEE:TOKEN...]")
+	}
+
+	tokens := make([]common.Address, 0, len(parts)/2+1)
+	fees := make([]int64, 0, len(parts)/2)
+	for i, part := range parts {
+		if i%2 == 1 {
+			fee, err := strconv.ParseUint(part, 10, 24)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid fee %s in route", part)
+			}
+			fees = append(fees, int64(fee))
+			continue
+		}
+
+		if strings.EqualFold(part, WRAPPED_Q_ROUTE_ALIAS) {
+			tokens = append(tokens, wqAddress)
+			continue
+		}
+		if common.IsHexAddress(part) == false {
+			return nil, nil, fmt.Errorf("invalid token address %s in route", part)
+		}
+		tokens = append(tokens, common.HexToAddress(part))
+	}
+
+	return tokens, fees, nil
+}
+
+// encodePath packs tokens and fees into the path bytes expected by exactInput.
+// exactOutput expects the path in reverse order (from the output token to the input token), pass reverse = true for it.
+func encodePath(tokens []common.Address, fees []int64, reverse bool) ([]byte, error) {
+	if len(tokens) != len(fees)+1 {
+		return nil, errors.New("path should have exactly one more token than fees")
+	}
+
+	path := make([]byte, 0, len(tokens)*common.AddressLength+len(fees)*PATH_FEE_SIZE)
+	for i := range tokens {
+		index := i
+		if reverse {
+			index = len(tokens) - 1 - i
+		}
+		path = append(path, tokens[index].Bytes()...)
+
+		if i < len(fees) {
+			feeIndex := i
+			if reverse {
+				feeIndex = len(fees) - 1 - i
+			}
+			fee := fees[feeIndex]
+			path = append(path, byte(fee>>16), byte(fee>>8), byte(fee))
+		}
+	}
+
+	return path, nil
+}
+
+// decodePath unpacks path bytes of exactInput or exactOutput into its tokens and fees, in the order they are encoded
+func decodePath(path []byte) ([]common.Address, []int64, error) {
+	hopSize := common.AddressLength + PATH_FEE_SIZE
+	if len(path) < common.AddressLength || (len(path)-common.AddressLength)%hopSize != 0 {
+		return nil, nil, fmt.Errorf("invalid path length %d", len(path))
+	}
+
+	hops := (len(path) - common.AddressLength) / hopSize
+	tokens := make([]common.Address, 0, hops+1)
+	fees := make([]int64, 0, hops)
+	for i := 0; i < hops; i++ {
+		offset := i * hopSize
+		tokens = append(tokens, common.BytesToAddress(path[offset:offset+common.AddressLength]))
+		fee := path[offset+common.AddressLength : offset+hopSize]
+		fees = append(fees, int64(fee[0])<<16|int64(fee[1])<<8|int64(fee[2]))
+	}
+	tokens = append(tokens, common.BytesToAddress(path[hops*hopSize:]))
+
+	return tokens, fees, nil
+}
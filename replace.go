@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	ethereum "github.com/quantumcoinproject/quantum-coin-go"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Replacement of a pending transaction with another one at the same nonce. The transaction pool only accepts the replacement
+// when its gas price is higher than the original's by at least the price bump of the pool, 10% by default.
+
+const MIN_REPLACEMENT_BUMP_PERCENT = 10
+
+// getReplacementGasPrice returns the gas price of the original transaction raised by bumpPercent, rounded up, or the suggested
+// gas price of the node when that is higher
+func getReplacementGasPrice(originalGasPrice *big.Int, suggestedGasPrice *big.Int, bumpPercent int64) *big.Int {
+	numerator := new(big.Int).Mul(originalGasPrice, big.NewInt(100+bumpPercent))
+	gasPrice := new(big.Int).Div(numerator, big.NewInt(100))
+	if new(big.Int).Mod(numerator, big.NewInt(100)).Sign() != 0 {
+		gasPrice.Add(gasPrice, big.NewInt(1))
+	}
+	if suggestedGasPrice != nil && suggestedGasPrice.Cmp(gasPrice) > 0 {
+		return suggestedGasPrice
+	}
+	return gasPrice
+}
+
+// replaceTransaction re-sends the pending transaction txHash of fromAddress with a higher gas price. With cancel, the replacement is
+// a zero-value transfer to fromAddress, so that the original is dropped when the replacement is mined
+func replaceTransaction(txHash common.Hash, cancel bool, bumpPercent int64) (*types.Transaction, error) {
+	if bumpPercent < MIN_REPLACEMENT_BUMP_PERCENT {
+		return nil, fmt.Errorf("the gas price should be raised by at least %d%% to replace a transaction", MIN_REPLACEMENT_BUMP_PERCENT)
+	}
+
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	original, isPending, err := client.TransactionByHash(context.Background(), txHash)
+	if err != nil {
+		return nil, err
+	}
+	if isPending == false {
+		return nil, errors.New("the transaction is already mined and cannot be replaced")
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		return nil, err
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(chainId)), original)
+	if err != nil {
+		return nil, err
+	}
+	if sender.IsEqualTo(fromAddress) == false {
+		return nil, fmt.Errorf("the transaction was sent by %s, not FROM_ADDRESS %s", sender, fromAddress)
+	}
+
+	suggestedGasPrice, err := client.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := getReplacementGasPrice(original.GasPrice(), suggestedGasPrice, bumpPercent)
+
+	var replacement *types.Transaction
+	if cancel {
+		gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{From: fromAddress, To: &fromAddress, Value: big.NewInt(0)})
+		if err != nil {
+			return nil, err
+		}
+		replacement = types.NewTransaction(original.Nonce(), fromAddress, big.NewInt(0), gasLimit, gasPrice, nil)
+	} else {
+		if original.To() == nil {
+			return nil, errors.New("contract deployments cannot be sped up, cancel the transaction and deploy again")
+		}
+		replacement = types.NewTransaction(original.Nonce(), *original.To(), original.Value(), original.Gas(), gasPrice, original.Data())
+	}
+
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		return nil, err
+	}
+
+	signedTx, err := txnOpts.Signer(fromAddress, replacement)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Println("nonce", original.Nonce(), "gas price", original.GasPrice(), "->", gasPrice)
+
+	err = client.SendTransaction(context.Background(), signedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cancel {
+		fmt.Println("Your request to cancel the transaction has been added to the queue for processing. Please check your account after 10 minutes.")
+	} else {
+		fmt.Println("Your request to speed up the transaction has been added to the queue for processing. Please check your account after 10 minutes.")
+	}
+	fmt.Println("The transaction hash for tracking this request is: ", signedTx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return signedTx, nil
+}
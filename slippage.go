@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"quantumswap-cli/contracts/core"
+	"quantumswap-cli/contracts/corev2"
+	"quantumswap-cli/contracts/pairv2"
+	"quantumswap-cli/contracts/v2swaprouter"
+	"quantumswap-cli/contracts/v3pool"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+	"github.com/quantumcoinproject/quantum-coin-go/params"
+)
+
+// Slippage tolerance. With --slippage PERCENT, the swap and liquidity commands quote the expected amounts and derive
+// AMOUNT_OUT_MIN, AMOUNT_IN_MAX, AMOUNT_A_MIN and AMOUNT_B_MIN from them, instead of taking them as arguments.
+
+const SLIPPAGE_FLAG = "--slippage"
+
+// An explicit minimum below this percentage of the expected amount gives no real protection
+const NO_PROTECTION_PERCENT = 1
+
+// slippageTolerance is the percentage given with --slippage, nil when the bounds are given as arguments
+var slippageTolerance *big.Rat
+
+func parseSlippage(value string) (*big.Rat, error) {
+	percent, err := ParseBigRat(value)
+	if err != nil || percent.Sign() < 0 || percent.Cmp(big.NewRat(100, 1)) >= 0 {
+		return nil, fmt.Errorf("invalid %s %s, it should be a percentage from 0 to less than 100, for example 0.5", SLIPPAGE_FLAG, value)
+	}
+	return percent, nil
+}
+
+// getSlippagePercent returns the --slippage percentage, or 0.5 for the commands that always derive their bounds
+func getSlippagePercent() *big.Rat {
+	if slippageTolerance != nil {
+		return slippageTolerance
+	}
+	return big.NewRat(1, 2)
+}
+
+// addSlippage returns ceil(amount * (100 + slippagePercent) / 100), the counterpart of applySlippage for maximums
+func addSlippage(amount *big.Int, slippagePercent *big.Rat) *big.Int {
+	factor := new(big.Rat).Add(big.NewRat(100, 1), slippagePercent)
+	factor.Quo(factor, big.NewRat(100, 1))
+	result := new(big.Rat).Mul(new(big.Rat).SetInt(amount), factor)
+
+	quotient, remainder := new(big.Int).QuoRem(result.Num(), result.Denom(), new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
+
+// parseAmountBound parses an explicit AMOUNT_*_MIN or AMOUNT_IN_MAX argument in whole tokens (18 decimals) to wei
+func parseAmountBound(value string) (*big.Int, error) {
+	amount, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return params.EtherToWei(new(big.Int).SetUint64(amount)), nil
+}
+
+// warnIfNoProtection warns when an explicit minimum is zero, or below NO_PROTECTION_PERCENT of the expected amount.
+// expected is nil when it is not known.
+func warnIfNoProtection(name string, minimum *big.Int, expected *big.Int) {
+	if minimum.Sign() == 0 {
+		fmt.Println("Warning:", name, "is 0, which gives no protection against price movement. Use", SLIPPAGE_FLAG, "to derive it from a quote.")
+		return
+	}
+	if expected == nil || expected.Sign() == 0 {
+		return
+	}
+	if new(big.Int).Mul(minimum, big.NewInt(100)).Cmp(new(big.Int).Mul(expected, big.NewInt(NO_PROTECTION_PERCENT))) < 0 {
+		fmt.Printf("Warning: %s %s is less than %d%% of the expected %s and gives almost no protection against price movement. Use %s to derive it from a quote.\n",
+			name, formatWei(minimum), NO_PROTECTION_PERCENT, formatWei(expected), SLIPPAGE_FLAG)
+	}
+}
+
+// warnIfNoMaxProtection is the counterpart of warnIfNoProtection for maximums: it warns when an explicit maximum is more than
+// 100 / NO_PROTECTION_PERCENT times the expected amount. expected is nil when it is not known.
+func warnIfNoMaxProtection(name string, maximum *big.Int, expected *big.Int) {
+	if expected == nil || expected.Sign() == 0 {
+		return
+	}
+	if new(big.Int).Mul(expected, big.NewInt(100)).Cmp(new(big.Int).Mul(maximum, big.NewInt(NO_PROTECTION_PERCENT))) < 0 {
+		fmt.Printf("Warning: %s %s is more than %d times the expected %s and gives almost no protection against price movement. Use %s to derive it from a quote.\n",
+			name, formatWei(maximum), 100/NO_PROTECTION_PERCENT, formatWei(expected), SLIPPAGE_FLAG)
+	}
+}
+
+// getV2SwapQuote returns the amount out of swapExactTokensForTokens from the reserves of the pair, via getAmountsOut of the router
+func getV2SwapQuote(tokenInAddress common.Address, tokenOutAddress common.Address, amountIn *big.Int) (*big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v2swaprouter.NewV2swaprouter(v2SwapRouterContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts, err := contract.GetAmountsOut(nil, amountIn, []common.Address{tokenInAddress, tokenOutAddress})
+	if err != nil {
+		return nil, err
+	}
+
+	return amounts[len(amounts)-1], nil
+}
+
+// getV2LiquidityAmounts returns the amounts addLiquidity of the v2 router deposits: the desired amounts for a new pair,
+// otherwise one desired amount and the other in the ratio of the reserves
+func getV2LiquidityAmounts(tokenAaddress common.Address, tokenBaddress common.Address, amountAdesired *big.Int, amountBdesired *big.Int) (*big.Int, *big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	router, err := v2swaprouter.NewV2swaprouter(v2SwapRouterContractAddress, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factoryAddress, err := router.Factory(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factory, err := corev2.NewCorev2(factoryAddress, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pairAddress, err := factory.GetPair(nil, tokenAaddress, tokenBaddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pairAddress.IsEqualTo(common.Address{}) {
+		return amountAdesired, amountBdesired, nil
+	}
+
+	pair, err := pairv2.NewPairv2(pairAddress, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reserves, err := pair.GetReserves(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if reserves.Reserve0.Sign() == 0 && reserves.Reserve1.Sign() == 0 {
+		return amountAdesired, amountBdesired, nil
+	}
+
+	reserveA, reserveB := reserves.Reserve0, reserves.Reserve1
+	if bytes.Compare(tokenAaddress.Bytes(), tokenBaddress.Bytes()) > 0 {
+		reserveA, reserveB = reserveB, reserveA
+	}
+
+	// Same as UniswapV2Library.quote
+	amountBoptimal := new(big.Int).Div(new(big.Int).Mul(amountAdesired, reserveB), reserveA)
+	if amountBoptimal.Cmp(amountBdesired) <= 0 {
+		return amountAdesired, amountBoptimal, nil
+	}
+	amountAoptimal := new(big.Int).Div(new(big.Int).Mul(amountBdesired, reserveA), reserveB)
+	return amountAoptimal, amountBdesired, nil
+}
+
+// getV3PoolSqrtPrice returns the current sqrtPriceX96 of the pool, or nil when the pool does not exist or is not initialized
+func getV3PoolSqrtPrice(tokenAaddress common.Address, tokenBaddress common.Address, fee int64) (*big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	factoryAddress, err := getV3FactoryAddress(client)
+	if err != nil {
+		return nil, err
+	}
+
+	factory, err := core.NewCore(factoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	poolAddress, err := factory.GetPool(nil, tokenAaddress, tokenBaddress, big.NewInt(fee))
+	if err != nil {
+		return nil, err
+	}
+	if poolAddress.IsEqualTo(common.Address{}) {
+		return nil, nil
+	}
+
+	pool, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	slot0, err := pool.Slot0(nil)
+	if err != nil {
+		return nil, err
+	}
+	if slot0.SqrtPriceX96.Sign() == 0 {
+		return nil, nil
+	}
+
+	return slot0.SqrtPriceX96, nil
+}
+
+// getV3LiquidityAmounts returns the amounts of token A and token B that minting with the desired amounts deposits at sqrtPriceX96
+func getV3LiquidityAmounts(tokenAaddress common.Address, tokenBaddress common.Address, sqrtPriceX96 *big.Int, tickLower int32, tickUpper int32,
+	amountAdesired *big.Int, amountBdesired *big.Int) (*big.Int, *big.Int, error) {
+	sqrtRatioAX96, err := getSqrtRatioAtTick(tickLower)
+	if err != nil {
+		return nil, nil, err
+	}
+	sqrtRatioBX96, err := getSqrtRatioAtTick(tickUpper)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokenAisToken0 := bytes.Compare(tokenAaddress.Bytes(), tokenBaddress.Bytes()) < 0
+	amount0Desired, amount1Desired := amountAdesired, amountBdesired
+	if tokenAisToken0 == false {
+		amount0Desired, amount1Desired = amountBdesired, amountAdesired
+	}
+
+	liquidity, err := getLiquidityForAmounts(sqrtPriceX96, sqrtRatioAX96, sqrtRatioBX96, amount0Desired, amount1Desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	if liquidity.Sign() == 0 {
+		return nil, nil, errors.New("the amounts give no liquidity in the range at the current price")
+	}
+
+	amount0, amount1, err := getAmountsForLiquidity(sqrtPriceX96, sqrtRatioAX96, sqrtRatioBX96, liquidity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tokenAisToken0 == false {
+		return amount1, amount0, nil
+	}
+	return amount0, amount1, nil
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Port of the SqrtPriceMath library of the v3 core contracts, together with the FullMath and UnsafeMath helpers it depends on.
+// Contains the math that uses square root of price as a Q64.96 and liquidity to compute deltas.
+// Intermediate values are checked against the uint256 bounds of the contracts, so that results match the contracts bit for bit.
+
+var errSqrtPriceMath = errors.New("sqrt price math require failed")
+var errFullMathOverflow = errors.New("full math result overflows uint256")
+
+// mulDiv calculates floor(a*b/denominator) with full precision.
+// Throws if result overflows a uint256 or denominator == 0
+func mulDiv(a *big.Int, b *big.Int, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, errFullMathOverflow
+	}
+	result := new(big.Int).Mul(a, b)
+	result.Quo(result, denominator)
+	if result.Cmp(MAX_UINT256) > 0 {
+		return nil, errFullMathOverflow
+	}
+	return result, nil
+}
+
+// mulDivRoundingUp calculates ceil(a*b/denominator) with full precision.
+// Throws if result overflows a uint256 or denominator == 0
+func mulDivRoundingUp(a *big.Int, b *big.Int, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, errFullMathOverflow
+	}
+	result, remainder := new(big.Int).QuoRem(new(big.Int).Mul(a, b), denominator, new(big.Int))
+	if remainder.Sign() > 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	if result.Cmp(MAX_UINT256) > 0 {
+		return nil, errFullMathOverflow
+	}
+	return result, nil
+}
+
+// divRoundingUp returns ceil(x / y). Division by zero returns 0 like the contracts do
+func divRoundingUp(x *big.Int, y *big.Int) *big.Int {
+	if y.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	result, remainder := new(big.Int).QuoRem(x, y, new(big.Int))
+	if remainder.Sign() > 0 {
+		result.Add(result, big.NewInt(1))
+	}
+	return result
+}
+
+// getNextSqrtPriceFromAmount0RoundingUp gets the next sqrt price given a delta of token0
+// Always rounds up, because in the exact output case (increasing price) we need to move the price at least
+// far enough to get the desired output amount, and in the exact input case (decreasing price) we need to move the
+// price less in order to not send too much output.
+func getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96 *big.Int, liquidity *big.Int, amount *big.Int, add bool) (*big.Int, error) {
+	// we short circuit amount == 0 because the result is otherwise not guaranteed to equal the input price
+	if amount.Sign() == 0 {
+		return new(big.Int).Set(sqrtPX96), nil
+	}
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	product := new(big.Int).Mul(amount, sqrtPX96)
+
+	if add {
+		if product.Cmp(MAX_UINT256) <= 0 {
+			denominator := new(big.Int).Add(numerator1, product)
+			if denominator.Cmp(MAX_UINT256) <= 0 {
+				// always fits in 160 bits
+				return mulDivRoundingUp(numerator1, sqrtPX96, denominator)
+			}
+		}
+
+		return divRoundingUp(numerator1, new(big.Int).Add(new(big.Int).Quo(numerator1, sqrtPX96), amount)), nil
+	}
+
+	// if the product overflows, we know the denominator underflows
+	// in addition, we must check that the denominator does not underflow
+	if product.Cmp(MAX_UINT256) > 0 || numerator1.Cmp(product) <= 0 {
+		return nil, errSqrtPriceMath
+	}
+	denominator := new(big.Int).Sub(numerator1, product)
+	result, err := mulDivRoundingUp(numerator1, sqrtPX96, denominator)
+	if err != nil {
+		return nil, err
+	}
+	if result.Cmp(MAX_UINT160) > 0 {
+		return nil, errSqrtPriceMath
+	}
+	return result, nil
+}
+
+// getNextSqrtPriceFromAmount1RoundingDown gets the next sqrt price given a delta of token1
+// Always rounds down, because in the exact output case (decreasing price) we need to move the price at least
+// far enough to get the desired output amount, and in the exact input case (increasing price) we need to move the
+// price less in order to not send too much output.
+func getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96 *big.Int, liquidity *big.Int, amount *big.Int, add bool) (*big.Int, error) {
+	// if we're adding (subtracting), rounding down requires rounding the quotient down (up)
+	// in both cases, avoid a mulDiv for most inputs
+	if add {
+		quotient, err := mulDiv(amount, Q96, liquidity)
+		if err != nil {
+			return nil, err
+		}
+		result := quotient.Add(quotient, sqrtPX96)
+		if result.Cmp(MAX_UINT160) > 0 {
+			return nil, errSqrtPriceMath
+		}
+		return result, nil
+	}
+
+	quotient, err := mulDivRoundingUp(amount, Q96, liquidity)
+	if err != nil {
+		return nil, err
+	}
+	if sqrtPX96.Cmp(quotient) <= 0 {
+		return nil, errSqrtPriceMath
+	}
+	// always fits 160 bits
+	return quotient.Sub(sqrtPX96, quotient), nil
+}
+
+// getNextSqrtPriceFromInput gets the next sqrt price given an input amount of token0 or token1
+// Throws if price or liquidity are 0, or if the next price is out of bounds
+func getNextSqrtPriceFromInput(sqrtPX96 *big.Int, liquidity *big.Int, amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 || liquidity.Sign() <= 0 {
+		return nil, errSqrtPriceMath
+	}
+
+	// round to make sure that we don't pass the target price
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountIn, true)
+	}
+	return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountIn, true)
+}
+
+// getNextSqrtPriceFromOutput gets the next sqrt price given an output amount of token0 or token1
+// Throws if price or liquidity are 0 or the next price is out of bounds
+func getNextSqrtPriceFromOutput(sqrtPX96 *big.Int, liquidity *big.Int, amountOut *big.Int, zeroForOne bool) (*big.Int, error) {
+	if sqrtPX96.Sign() <= 0 || liquidity.Sign() <= 0 {
+		return nil, errSqrtPriceMath
+	}
+
+	// round to make sure that we pass the target price
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountOut, false)
+	}
+	return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountOut, false)
+}
+
+// getAmount0Delta gets the amount0 delta between two prices
+// Calculates liquidity / sqrt(lower) - liquidity / sqrt(upper),
+// i.e. liquidity * (sqrt(upper) - sqrt(lower)) / (sqrt(upper) * sqrt(lower))
+func getAmount0Delta(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+	if sqrtRatioAX96.Sign() <= 0 {
+		return nil, errSqrtPriceMath
+	}
+
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	numerator2 := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+
+	if roundUp {
+		amount, err := mulDivRoundingUp(numerator1, numerator2, sqrtRatioBX96)
+		if err != nil {
+			return nil, err
+		}
+		return divRoundingUp(amount, sqrtRatioAX96), nil
+	}
+
+	amount, err := mulDiv(numerator1, numerator2, sqrtRatioBX96)
+	if err != nil {
+		return nil, err
+	}
+	return amount.Quo(amount, sqrtRatioAX96), nil
+}
+
+// getAmount1Delta gets the amount1 delta between two prices
+// Calculates liquidity * (sqrt(upper) - sqrt(lower))
+func getAmount1Delta(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+
+	if roundUp {
+		return mulDivRoundingUp(liquidity, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96), Q96)
+	}
+	return mulDiv(liquidity, new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96), Q96)
+}
+
+// getAmount0DeltaSigned is the helper that gets the signed token0 delta for a signed change in liquidity
+func getAmount0DeltaSigned(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int) (*big.Int, error) {
+	if liquidity.Sign() < 0 {
+		amount, err := getAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, new(big.Int).Neg(liquidity), false)
+		if err != nil {
+			return nil, err
+		}
+		return amount.Neg(amount), nil
+	}
+	return getAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity, true)
+}
+
+// getAmount1DeltaSigned is the helper that gets the signed token1 delta for a signed change in liquidity
+func getAmount1DeltaSigned(sqrtRatioAX96 *big.Int, sqrtRatioBX96 *big.Int, liquidity *big.Int) (*big.Int, error) {
+	if liquidity.Sign() < 0 {
+		amount, err := getAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, new(big.Int).Neg(liquidity), false)
+		if err != nil {
+			return nil, err
+		}
+		return amount.Neg(amount), nil
+	}
+	return getAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity, true)
+}
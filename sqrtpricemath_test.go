@@ -0,0 +1,74 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Vectors from SqrtPriceMath.spec.ts of the v3 core contracts
+func TestGetAmountDelta(t *testing.T) {
+	price := fromDecimalString("79228162514264337593543950336")    // encodePriceSqrt(1, 1)
+	price121 := fromDecimalString("87150978765690771352898345369") // encodePriceSqrt(121, 100)
+	price2 := fromDecimalString("112045541949572279837463876454")  // encodePriceSqrt(2, 1)
+	oneEther := fromDecimalString("1000000000000000000")
+
+	tests := []struct {
+		name        string
+		sqrtRatioA  *big.Int
+		sqrtRatioB  *big.Int
+		liquidity   *big.Int
+		amount0Up   string
+		amount0Down string
+		amount1Up   string
+		amount1Down string
+	}{
+		{"returns 0 if liquidity is 0", price, price2, big.NewInt(0), "0", "0", "0", "0"},
+		{"returns 0 if prices are equal", price, price, oneEther, "0", "0", "0", "0"},
+		{"price of 1 to 1.21", price, price121, oneEther,
+			"90909090909090910", "90909090909090909", "100000000000000000", "99999999999999999"},
+		{"prices in either order", price121, price, oneEther,
+			"90909090909090910", "90909090909090909", "100000000000000000", "99999999999999999"},
+	}
+
+	for _, test := range tests {
+		for _, roundUp := range []bool{true, false} {
+			want0, want1 := test.amount0Down, test.amount1Down
+			if roundUp {
+				want0, want1 = test.amount0Up, test.amount1Up
+			}
+
+			amount0, err := getAmount0Delta(test.sqrtRatioA, test.sqrtRatioB, test.liquidity, roundUp)
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+			} else if amount0.String() != want0 {
+				t.Errorf("%s: amount0 rounding up %v: %v, want %v", test.name, roundUp, amount0, want0)
+			}
+
+			amount1, err := getAmount1Delta(test.sqrtRatioA, test.sqrtRatioB, test.liquidity, roundUp)
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+			} else if amount1.String() != want1 {
+				t.Errorf("%s: amount1 rounding up %v: %v, want %v", test.name, roundUp, amount1, want1)
+			}
+		}
+	}
+}
+
+// "works for prices that overflow": the product of the sqrt prices exceeds uint256, rounding up is one more than rounding down
+func TestGetAmount0DeltaOverflowingPrices(t *testing.T) {
+	sqrtRatioA := fromDecimalString("2787593149816327892691964784081045188247552")  // encodePriceSqrt(2^90, 1)
+	sqrtRatioB := fromDecimalString("22300745198530623141535718272648361505980416") // encodePriceSqrt(2^96, 1)
+	liquidity := fromDecimalString("1000000000000000000")
+
+	amountUp, err := getAmount0Delta(sqrtRatioA, sqrtRatioB, liquidity, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amountDown, err := getAmount0Delta(sqrtRatioA, sqrtRatioB, liquidity, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amountUp.String() != "24869" || amountDown.String() != "24868" {
+		t.Errorf("amount0 %v rounding up and %v rounding down, want 24869 and 24868", amountUp, amountDown)
+	}
+}
@@ -0,0 +1,768 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"quantumswap-cli/contracts/nonfungiblepositionmanager"
+	"quantumswap-cli/contracts/v3staker"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/core/types"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+	"github.com/quantumcoinproject/quantum-coin-go/params"
+)
+
+// Liquidity mining with UniswapV3Staker. An incentive pays REWARD tokens to the positions staked in a pool between its start
+// and end time, in proportion to the liquidity and the seconds they spent in range. On the command line an incentive is
+// identified by its key, written as REWARD_TOKEN:POOL:START_TIME:END_TIME:REFUNDEE with the times in unix seconds.
+
+const INCENTIVE_KEY_PARTS = 5
+
+// parseIncentiveKey parses an incentive key such as REWARD_TOKEN:POOL:START_TIME:END_TIME:REFUNDEE
+func parseIncentiveKey(value string) (v3staker.IUniswapV3StakerIncentiveKey, error) {
+	var key v3staker.IUniswapV3StakerIncentiveKey
+
+	parts := strings.Split(value, ":")
+	if len(parts) != INCENTIVE_KEY_PARTS {
+		return key, errors.New("incentive should be in the form REWARD_TOKEN:POOL:START_TIME:END_TIME:REFUNDEE")
+	}
+
+	if common.IsHexAddress(parts[0]) == false {
+		return key, fmt.Errorf("invalid reward token address %s in incentive", parts[0])
+	}
+	if common.IsHexAddress(parts[1]) == false {
+		return key, fmt.Errorf("invalid pool address %s in incentive", parts[1])
+	}
+	startTime, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return key, fmt.Errorf("invalid start time %s in incentive", parts[2])
+	}
+	endTime, err := strconv.ParseUint(parts[3], 10, 64)
+	if err != nil {
+		return key, fmt.Errorf("invalid end time %s in incentive", parts[3])
+	}
+	if common.IsHexAddress(parts[4]) == false {
+		return key, fmt.Errorf("invalid refundee address %s in incentive", parts[4])
+	}
+
+	key.RewardToken = common.HexToAddress(parts[0])
+	key.Pool = common.HexToAddress(parts[1])
+	key.StartTime = new(big.Int).SetUint64(startTime)
+	key.EndTime = new(big.Int).SetUint64(endTime)
+	key.Refundee = common.HexToAddress(parts[4])
+
+	return key, nil
+}
+
+func formatIncentiveKey(key v3staker.IUniswapV3StakerIncentiveKey) string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s", key.RewardToken.Hex(), key.Pool.Hex(), key.StartTime, key.EndTime, key.Refundee.Hex())
+}
+
+// encodeIncentiveKey abi encodes the key. Every field is static, so the encoding is one 32 byte word per field
+func encodeIncentiveKey(key v3staker.IUniswapV3StakerIncentiveKey) []byte {
+	encoded := make([]byte, 0, INCENTIVE_KEY_PARTS*32)
+	encoded = append(encoded, common.LeftPadBytes(key.RewardToken.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(key.Pool.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(key.StartTime.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(key.EndTime.Bytes(), 32)...)
+	encoded = append(encoded, common.LeftPadBytes(key.Refundee.Bytes(), 32)...)
+	return encoded
+}
+
+// getIncentiveId returns keccak256(abi.encode(key)), the id the staker stores the incentive under. Port of IncentiveId.compute
+func getIncentiveId(key v3staker.IUniswapV3StakerIncentiveKey) [32]byte {
+	return crypto.Keccak256Hash(encodeIncentiveKey(key))
+}
+
+// resolveIncentiveTime parses a unix time in seconds, or a number of seconds from now when prefixed with +
+func resolveIncentiveTime(value string, now uint64) (uint64, error) {
+	if strings.HasPrefix(value, "+") {
+		seconds, err := strconv.ParseUint(value[1:], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return now + seconds, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func getLatestBlockTime() (uint64, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return 0, err
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Time, nil
+}
+
+// validateIncentiveTimes applies the checks of UniswapV3Staker.createIncentive, against the limits the staker is deployed with
+func validateIncentiveTimes(startTime uint64, endTime uint64, now uint64) error {
+	if startTime < now {
+		return errors.New("start time must be now or in the future")
+	}
+	if startTime-now > MAX_INCENTIVE_START_LEAD_TIME {
+		return fmt.Errorf("start time is too far into the future, it should be at most %d seconds from now", MAX_INCENTIVE_START_LEAD_TIME)
+	}
+	if startTime >= endTime {
+		return errors.New("start time must be before end time")
+	}
+	if endTime-startTime > MAX_INCENTIVE_DURATION {
+		return fmt.Errorf("incentive duration is too long, it should be at most %d seconds", MAX_INCENTIVE_DURATION)
+	}
+	return nil
+}
+
+func createIncentive(incentiveKey v3staker.IUniswapV3StakerIncentiveKey, reward int64) (*types.Transaction, error) {
+	if reward <= 0 {
+		return nil, errors.New("reward must be positive")
+	}
+
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	err = validateIncentiveTimes(incentiveKey.StartTime.Uint64(), incentiveKey.EndTime.Uint64(), header.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	var tx *types.Transaction
+	tx, err = contract.CreateIncentive(txnOpts, incentiveKey, params.EtherToWei(big.NewInt(reward)))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to create an incentive has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+func getIncentive(incentiveKey v3staker.IUniswapV3StakerIncentiveKey) (*big.Int, *big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	incentive, err := contract.Incentives(nil, getIncentiveId(incentiveKey))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return incentive.TotalRewardUnclaimed, incentive.NumberOfStakes, nil
+}
+
+// getStakerRewards returns the rewards of rewardToken that owner can claim, i.e. the rewards of positions already unstaked
+func getStakerRewards(rewardToken common.Address, owner common.Address) (*big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.Rewards(nil, rewardToken, owner)
+}
+
+// getStakeRewardInfo returns the reward accrued so far by a staked position, which becomes claimable once it is unstaked
+func getStakeRewardInfo(incentiveKey v3staker.IUniswapV3StakerIncentiveKey, tokenId *big.Int) (*big.Int, *big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rewardInfo, err := contract.GetRewardInfo(nil, incentiveKey, tokenId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rewardInfo.Reward, rewardInfo.SecondsInsideX128, nil
+}
+
+// depositPosition transfers the position NFT to the staker with safeTransferFrom. When incentiveKey is given, the staker
+// also stakes the position in that incentive, in the same transaction
+func depositPosition(tokenId *big.Int, incentiveKey *v3staker.IUniswapV3StakerIncentiveKey) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	staker, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	positionManagerAddress, err := staker.NonfungiblePositionManager(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := nonfungiblepositionmanager.NewNonfungiblepositionmanager(positionManagerAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := contract.OwnerOf(nil, tokenId)
+	if err != nil {
+		return nil, err
+	}
+	if owner.IsEqualTo(fromAddress) == false {
+		return nil, fmt.Errorf("position %s is owned by %s, not by FROM_ADDRESS", tokenId, owner)
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	if incentiveKey == nil {
+		tx, err = contract.SafeTransferFrom(txnOpts, fromAddress, v3StakerContractAddress, tokenId)
+	} else {
+		tx, err = contract.SafeTransferFrom0(txnOpts, fromAddress, v3StakerContractAddress, tokenId, encodeIncentiveKey(*incentiveKey))
+	}
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to deposit the position has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+func stakePosition(incentiveKey v3staker.IUniswapV3StakerIncentiveKey, tokenId *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	deposit, err := contract.Deposits(nil, tokenId)
+	if err != nil {
+		return nil, err
+	}
+	if deposit.Owner.IsEqualTo(fromAddress) == false {
+		return nil, fmt.Errorf("position %s has not been deposited by FROM_ADDRESS, deposit it first", tokenId)
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.Time < incentiveKey.StartTime.Uint64() {
+		return nil, errors.New("incentive has not started yet")
+	}
+	if header.Time >= incentiveKey.EndTime.Uint64() {
+		return nil, errors.New("incentive has ended")
+	}
+
+	incentive, err := contract.Incentives(nil, getIncentiveId(incentiveKey))
+	if err != nil {
+		return nil, err
+	}
+	if incentive.TotalRewardUnclaimed.Sign() == 0 {
+		return nil, errors.New("incentive does not exist or has no rewards left")
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.StakeToken(txnOpts, incentiveKey, tokenId)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to stake the position has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// unstakePosition unstakes the position from the incentive and credits its reward to the owner of the deposit.
+// Before the incentive ends only the owner of the deposit can unstake, after it ends anyone can
+func unstakePosition(incentiveKey v3staker.IUniswapV3StakerIncentiveKey, tokenId *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	stake, err := contract.Stakes(nil, tokenId, getIncentiveId(incentiveKey))
+	if err != nil {
+		return nil, err
+	}
+	if stake.Liquidity.Sign() == 0 {
+		return nil, fmt.Errorf("position %s is not staked in the incentive", tokenId)
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.Time < incentiveKey.EndTime.Uint64() {
+		deposit, err := contract.Deposits(nil, tokenId)
+		if err != nil {
+			return nil, err
+		}
+		if deposit.Owner.IsEqualTo(fromAddress) == false {
+			return nil, fmt.Errorf("only the owner %s of the deposit can unstake before the incentive ends", deposit.Owner)
+		}
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.UnstakeToken(txnOpts, incentiveKey, tokenId)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to unstake the position has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// claimStakerReward transfers amount of the rewards owed to fromAddress to the recipient. An amount of 0 claims all of them
+func claimStakerReward(rewardToken common.Address, recipient common.Address, amount *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	owed, err := contract.Rewards(nil, rewardToken, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	if owed.Sign() == 0 {
+		return nil, errors.New("no rewards to claim, unstake the positions first")
+	}
+	if amount.Cmp(owed) > 0 {
+		return nil, fmt.Errorf("amount is more than the rewards owed %s", formatWei(owed))
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.ClaimReward(txnOpts, rewardToken, recipient, amount)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to claim rewards has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// withdrawPosition returns the position NFT from the staker to the recipient. The position must be unstaked from every incentive
+func withdrawPosition(tokenId *big.Int, recipient common.Address) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	deposit, err := contract.Deposits(nil, tokenId)
+	if err != nil {
+		return nil, err
+	}
+	if deposit.Owner.IsEqualTo(fromAddress) == false {
+		return nil, fmt.Errorf("position %s has not been deposited by FROM_ADDRESS", tokenId)
+	}
+	if deposit.NumberOfStakes.Sign() != 0 {
+		return nil, fmt.Errorf("position %s is still staked in %s incentives, unstake it first", tokenId, deposit.NumberOfStakes)
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.WithdrawToken(txnOpts, tokenId, recipient, []byte{})
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to withdraw the position has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// endIncentive refunds the unclaimed rewards of an incentive to its refundee. It can only be called after the end time,
+// once every position has been unstaked from the incentive
+func endIncentive(incentiveKey v3staker.IUniswapV3StakerIncentiveKey) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3staker.NewV3staker(v3StakerContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.Time < incentiveKey.EndTime.Uint64() {
+		return nil, fmt.Errorf("incentive can not be ended before its end time, %d seconds left", incentiveKey.EndTime.Uint64()-header.Time)
+	}
+
+	incentive, err := contract.Incentives(nil, getIncentiveId(incentiveKey))
+	if err != nil {
+		return nil, err
+	}
+	if incentive.TotalRewardUnclaimed.Sign() == 0 {
+		return nil, errors.New("incentive does not exist or has no rewards left to refund")
+	}
+	if incentive.NumberOfStakes.Sign() != 0 {
+		return nil, fmt.Errorf("%s positions are still staked in the incentive, unstake them first", incentive.NumberOfStakes)
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.EndIncentive(txnOpts, incentiveKey)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to end the incentive has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash(), "refund", formatWei(incentive.TotalRewardUnclaimed))
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"math/big"
+)
+
+// Port of the SwapMath library of the v3 core contracts.
+// Contains methods for computing the result of a swap within a single tick price range, i.e., a single tick.
+
+// The fee is expressed in hundredths of a bip, i.e. 1e-6
+var FEE_PIPS_DENOMINATOR = big.NewInt(1000000)
+
+// computeSwapStep computes the result of swapping some amount in, or amount out, given the parameters of the swap
+// The fee, plus the amount in, will never exceed the amount remaining if the swap's amountRemaining is positive.
+// amountRemaining is positive for exact input and negative for exact output.
+// Returns the price after swapping the amount in/out, not to exceed the price target, the amount to be swapped in,
+// the amount to be received and the amount of input that will be taken as a fee
+func computeSwapStep(sqrtRatioCurrentX96 *big.Int, sqrtRatioTargetX96 *big.Int, liquidity *big.Int, amountRemaining *big.Int,
+	feePips int64) (sqrtRatioNextX96 *big.Int, amountIn *big.Int, amountOut *big.Int, feeAmount *big.Int, err error) {
+	zeroForOne := sqrtRatioCurrentX96.Cmp(sqrtRatioTargetX96) >= 0
+	exactIn := amountRemaining.Sign() >= 0
+	fee := big.NewInt(feePips)
+	oneMinusFee := new(big.Int).Sub(FEE_PIPS_DENOMINATOR, fee)
+	amountRemainingAbs := new(big.Int).Abs(amountRemaining)
+
+	if exactIn {
+		amountRemainingLessFee, err := mulDiv(amountRemainingAbs, oneMinusFee, FEE_PIPS_DENOMINATOR)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if zeroForOne {
+			amountIn, err = getAmount0Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, true)
+		} else {
+			amountIn, err = getAmount1Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, true)
+		}
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if amountRemainingLessFee.Cmp(amountIn) >= 0 {
+			sqrtRatioNextX96 = sqrtRatioTargetX96
+		} else {
+			sqrtRatioNextX96, err = getNextSqrtPriceFromInput(sqrtRatioCurrentX96, liquidity, amountRemainingLessFee, zeroForOne)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+	} else {
+		if zeroForOne {
+			amountOut, err = getAmount1Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, false)
+		} else {
+			amountOut, err = getAmount0Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, false)
+		}
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if amountRemainingAbs.Cmp(amountOut) >= 0 {
+			sqrtRatioNextX96 = sqrtRatioTargetX96
+		} else {
+			sqrtRatioNextX96, err = getNextSqrtPriceFromOutput(sqrtRatioCurrentX96, liquidity, amountRemainingAbs, zeroForOne)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+	}
+
+	reachedTarget := sqrtRatioTargetX96.Cmp(sqrtRatioNextX96) == 0
+
+	// get the input/output amounts
+	if zeroForOne {
+		if reachedTarget == false || exactIn == false {
+			amountIn, err = getAmount0Delta(sqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, true)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+		if reachedTarget == false || exactIn {
+			amountOut, err = getAmount1Delta(sqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, false)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+	} else {
+		if reachedTarget == false || exactIn == false {
+			amountIn, err = getAmount1Delta(sqrtRatioCurrentX96, sqrtRatioNextX96, liquidity, true)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+		if reachedTarget == false || exactIn {
+			amountOut, err = getAmount0Delta(sqrtRatioCurrentX96, sqrtRatioNextX96, liquidity, false)
+			if err != nil {
+				return nil, nil, nil, nil, err
+			}
+		}
+	}
+
+	// cap the output amount to not exceed the remaining output amount
+	if exactIn == false && amountOut.Cmp(amountRemainingAbs) > 0 {
+		amountOut = amountRemainingAbs
+	}
+
+	if exactIn && sqrtRatioNextX96.Cmp(sqrtRatioTargetX96) != 0 {
+		// we didn't reach the target, so take the remainder of the maximum input as fee
+		feeAmount = new(big.Int).Sub(amountRemainingAbs, amountIn)
+	} else {
+		feeAmount, err = mulDivRoundingUp(amountIn, fee, oneMinusFee)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+	}
+
+	return sqrtRatioNextX96, amountIn, amountOut, feeAmount, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Vectors from SwapMath.spec.ts of the v3 core contracts
+func TestComputeSwapStep(t *testing.T) {
+	price := fromDecimalString("79228162514264337593543950336")           // encodePriceSqrt(1, 1)
+	priceTarget := fromDecimalString("79623317895830914510639640423")     // encodePriceSqrt(101, 100)
+	farPriceTarget := fromDecimalString("250541448375047931186413801569") // encodePriceSqrt(1000, 100)
+	twoEther := fromDecimalString("2000000000000000000")
+	oneEther := fromDecimalString("1000000000000000000")
+
+	// the intermediate insufficient liquidity cases start at 2^104
+	sqrtP := fromDecimalString("20282409603651670423947251286016")
+	sqrtPUp := new(big.Int).Div(new(big.Int).Mul(sqrtP, big.NewInt(11)), big.NewInt(10))
+	sqrtPDown := new(big.Int).Div(new(big.Int).Mul(sqrtP, big.NewInt(9)), big.NewInt(10))
+
+	tests := []struct {
+		name            string
+		current         *big.Int
+		target          *big.Int
+		liquidity       *big.Int
+		amountRemaining *big.Int
+		fee             int64
+		sqrtQ           *big.Int // nil when the price stops short of the target, checked separately
+		amountIn        string
+		amountOut       string
+		feeAmount       string
+	}{
+		{"exact amount in that gets capped at price target in one for zero", price, priceTarget, twoEther, oneEther, 600,
+			priceTarget, "9975124224178055", "9925619580021728", "5988667735148"},
+		{"exact amount out that gets capped at price target in one for zero", price, priceTarget, twoEther, new(big.Int).Neg(oneEther), 600,
+			priceTarget, "9975124224178055", "9925619580021728", "5988667735148"},
+		{"exact amount in that is fully spent in one for zero", price, farPriceTarget, twoEther, oneEther, 600,
+			nil, "999400000000000000", "666399946655997866", "600000000000000"},
+		{"exact amount out that is fully received in one for zero", price, farPriceTarget, twoEther, new(big.Int).Neg(oneEther), 600,
+			nil, "2000000000000000000", "1000000000000000000", "1200720432259356"},
+		{"amount out is capped at the desired amount out", fromDecimalString("417332158212080721273783715441582"),
+			fromDecimalString("1452870262520218020823638996"), fromDecimalString("159344665391607089467575320103"), big.NewInt(-1), 1,
+			fromDecimalString("417332158212080721273783715441581"), "1", "1", "1"},
+		{"target price of 1 uses partial input amount", big.NewInt(2), big.NewInt(1), big.NewInt(1),
+			fromDecimalString("3915081100057732413702495386755767"), 1,
+			big.NewInt(1), "39614081257132168796771975168", "0", "39614120871253040049813"},
+		{"entire input amount taken as fee", big.NewInt(2413), fromDecimalString("79887613182836312"),
+			fromDecimalString("1985041575832132834610021537970"), big.NewInt(10), 1872,
+			big.NewInt(2413), "0", "0", "10"},
+		{"handles intermediate insufficient liquidity in zero for one exact output case", sqrtP, sqrtPUp, big.NewInt(1024), big.NewInt(-4), 3000,
+			sqrtPUp, "26215", "0", "79"},
+		{"handles intermediate insufficient liquidity in one for zero exact output case", sqrtP, sqrtPDown, big.NewInt(1024), big.NewInt(-263000), 3000,
+			sqrtPDown, "1", "26214", "1"},
+	}
+
+	for _, test := range tests {
+		sqrtQ, amountIn, amountOut, feeAmount, err := computeSwapStep(test.current, test.target, test.liquidity, test.amountRemaining, test.fee)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+
+		if test.sqrtQ != nil && sqrtQ.Cmp(test.sqrtQ) != 0 {
+			t.Errorf("%s: sqrtQ %v, want %v", test.name, sqrtQ, test.sqrtQ)
+		}
+		if amountIn.String() != test.amountIn {
+			t.Errorf("%s: amountIn %v, want %v", test.name, amountIn, test.amountIn)
+		}
+		if amountOut.String() != test.amountOut {
+			t.Errorf("%s: amountOut %v, want %v", test.name, amountOut, test.amountOut)
+		}
+		if feeAmount.String() != test.feeAmount {
+			t.Errorf("%s: feeAmount %v, want %v", test.name, feeAmount, test.feeAmount)
+		}
+
+		// the fee plus the amount in never exceeds the amount remaining of an exact input step
+		if test.amountRemaining.Sign() > 0 && new(big.Int).Add(amountIn, feeAmount).Cmp(test.amountRemaining) > 0 {
+			t.Errorf("%s: amountIn plus fee %v exceeds the amount remaining %v", test.name, new(big.Int).Add(amountIn, feeAmount), test.amountRemaining)
+		}
+	}
+}
+
+func TestComputeSwapStepStopsShortOfTarget(t *testing.T) {
+	price := fromDecimalString("79228162514264337593543950336")
+	farPriceTarget := fromDecimalString("250541448375047931186413801569")
+	liquidity := fromDecimalString("2000000000000000000")
+	amount := fromDecimalString("1000000000000000000")
+
+	// exact input: the price moves by the whole input less the fee
+	sqrtQ, _, _, _, err := computeSwapStep(price, farPriceTarget, liquidity, amount, 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amountLessFee := new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(1000000-600)), big.NewInt(1000000))
+	want, err := getNextSqrtPriceFromInput(price, liquidity, amountLessFee, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqrtQ.Cmp(want) != 0 || sqrtQ.Cmp(farPriceTarget) >= 0 {
+		t.Errorf("exact input: sqrtQ %v, want %v", sqrtQ, want)
+	}
+
+	// exact output: the price moves by the whole output
+	sqrtQ, _, _, _, err = computeSwapStep(price, farPriceTarget, liquidity, new(big.Int).Neg(amount), 600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err = getNextSqrtPriceFromOutput(price, liquidity, amount, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqrtQ.Cmp(want) != 0 || sqrtQ.Cmp(farPriceTarget) >= 0 {
+		t.Errorf("exact output: sqrtQ %v, want %v", sqrtQ, want)
+	}
+}
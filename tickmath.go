@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Port of the TickMath library of the v3 core contracts.
+// Computes sqrt price for ticks of size 1.0001, i.e. sqrt(1.0001^tick) as fixed point Q64.96 numbers.
+// Supports prices between 2**-128 and 2**128
+
+// The minimum tick that may be passed to getSqrtRatioAtTick computed from log base 1.0001 of 2**-128
+const MIN_TICK = -887272
+
+// The maximum tick that may be passed to getSqrtRatioAtTick computed from log base 1.0001 of 2**128
+const MAX_TICK = -MIN_TICK
+
+// The minimum value that can be returned from getSqrtRatioAtTick. Equivalent to getSqrtRatioAtTick(MIN_TICK)
+var MIN_SQRT_RATIO = big.NewInt(4295128739)
+
+// The maximum value that can be returned from getSqrtRatioAtTick. Equivalent to getSqrtRatioAtTick(MAX_TICK)
+var MAX_SQRT_RATIO, _ = new(big.Int).SetString("1461446703485210103287273052203988822378723970342", 10)
+
+var Q96 = new(big.Int).Lsh(big.NewInt(1), 96)
+var Q128 = new(big.Int).Lsh(big.NewInt(1), 128)
+var Q192 = new(big.Int).Lsh(big.NewInt(1), 192)
+var MAX_UINT160 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+var MAX_UINT256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+var sqrtRatioTickMultipliers = []string{
+	"fff97272373d413259a46990580e213a", // 0x2
+	"fff2e50f5f656932ef12357cf3c7fdcc", // 0x4
+	"ffe5caca7e10e4e61c3624eaa0941cd0", // 0x8
+	"ffcb9843d60f6159c9db58835c926644", // 0x10
+	"ff973b41fa98c081472e6896dfb254c0", // 0x20
+	"ff2ea16466c96a3843ec78b326b52861", // 0x40
+	"fe5dee046a99a2a811c461f1969c3053", // 0x80
+	"fcbe86c7900a88aedcffc83b479aa3a4", // 0x100
+	"f987a7253ac413176f2b074cf7815e54", // 0x200
+	"f3392b0822b70005940c7a398e4b70f3", // 0x400
+	"e7159475a2c29b7443b29c7fa6e889d9", // 0x800
+	"d097f3bdfd2022b8845ad8f792aa5825", // 0x1000
+	"a9f746462d870fdf8a65dc1f90e061e5", // 0x2000
+	"70d869a156d2a1b890bb3df62baf32f7", // 0x4000
+	"31be135f97d08fd981231505542fcfa6", // 0x8000
+	"9aa508b5b7a84e1c677de54f3e99bc9",  // 0x10000
+	"5d6af8dedb81196699c329225ee604",   // 0x20000
+	"2216e584f5fa1ea926041bedfe98",     // 0x40000
+	"48a170391f7dc42444e8fa2",          // 0x80000
+}
+
+var errTickOutOfRange = errors.New("tick is out of range (T)")
+var errSqrtRatioOutOfRange = errors.New("sqrt ratio is out of range (R)")
+
+// getSqrtRatioAtTick calculates sqrt(1.0001^tick) * 2^96
+// Returns a Q64.96 number representing the sqrt of the ratio of the two assets (token1/token0) at the given tick
+func getSqrtRatioAtTick(tick int32) (*big.Int, error) {
+	absTick := int64(tick)
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	if absTick > MAX_TICK {
+		return nil, errTickOutOfRange
+	}
+
+	var ratio *big.Int
+	if absTick&0x1 != 0 {
+		ratio, _ = new(big.Int).SetString("fffcb933bd6fad37aa2d162d1a594001", 16)
+	} else {
+		ratio = new(big.Int).Set(Q128)
+	}
+	for i, multiplierHex := range sqrtRatioTickMultipliers {
+		if absTick&(int64(2)<<uint(i)) != 0 {
+			multiplier, _ := new(big.Int).SetString(multiplierHex, 16)
+			ratio.Mul(ratio, multiplier)
+			ratio.Rsh(ratio, 128)
+		}
+	}
+
+	if tick > 0 {
+		ratio.Div(MAX_UINT256, ratio)
+	}
+
+	// this divides by 1<<32 rounding up to go from a Q128.128 to a Q128.96.
+	// we then downcast because we know the result always fits within 160 bits due to our tick input constraint
+	// we round up in the division so getTickAtSqrtRatio of the output price is always consistent
+	remainder := new(big.Int).And(ratio, big.NewInt(0xffffffff))
+	sqrtPriceX96 := ratio.Rsh(ratio, 32)
+	if remainder.Sign() != 0 {
+		sqrtPriceX96.Add(sqrtPriceX96, big.NewInt(1))
+	}
+
+	return sqrtPriceX96, nil
+}
+
+// getTickAtSqrtRatio calculates the greatest tick value such that getSqrtRatioAtTick(tick) <= sqrtPriceX96
+// Throws in case sqrtPriceX96 < MIN_SQRT_RATIO, as MIN_SQRT_RATIO is the lowest value getSqrtRatioAtTick may ever return.
+func getTickAtSqrtRatio(sqrtPriceX96 *big.Int) (int32, error) {
+	// second inequality must be < because the price can never reach the price at the max tick
+	if sqrtPriceX96.Cmp(MIN_SQRT_RATIO) < 0 || sqrtPriceX96.Cmp(MAX_SQRT_RATIO) >= 0 {
+		return 0, errSqrtRatioOutOfRange
+	}
+
+	ratio := new(big.Int).Lsh(sqrtPriceX96, 32)
+	msb := ratio.BitLen() - 1
+
+	r := new(big.Int)
+	if msb >= 128 {
+		r.Rsh(ratio, uint(msb-127))
+	} else {
+		r.Lsh(ratio, uint(127-msb))
+	}
+
+	log2 := new(big.Int).Lsh(big.NewInt(int64(msb)-128), 64)
+	for shift := 63; shift >= 50; shift-- {
+		r.Mul(r, r)
+		r.Rsh(r, 127)
+		f := r.Bit(128)
+		log2.Or(log2, new(big.Int).Lsh(big.NewInt(int64(f)), uint(shift)))
+		r.Rsh(r, f)
+	}
+
+	// 128.128 number
+	logSqrt10001 := new(big.Int).Mul(log2, fromDecimalString("255738958999603826347141"))
+
+	tickLow := new(big.Int).Sub(logSqrt10001, fromDecimalString("3402992956809132418596140100660247210"))
+	tickLow.Rsh(tickLow, 128)
+	tickHi := new(big.Int).Add(logSqrt10001, fromDecimalString("291339464771989622907027621153398088495"))
+	tickHi.Rsh(tickHi, 128)
+
+	if tickLow.Cmp(tickHi) == 0 {
+		return int32(tickLow.Int64()), nil
+	}
+
+	sqrtRatioAtTickHi, err := getSqrtRatioAtTick(int32(tickHi.Int64()))
+	if err != nil {
+		return 0, err
+	}
+	if sqrtRatioAtTickHi.Cmp(sqrtPriceX96) <= 0 {
+		return int32(tickHi.Int64()), nil
+	}
+	return int32(tickLow.Int64()), nil
+}
+
+// clampTick limits a tick to the range MIN_TICK to MAX_TICK
+func clampTick(tick int64) int32 {
+	if tick < MIN_TICK {
+		return MIN_TICK
+	}
+	if tick > MAX_TICK {
+		return MAX_TICK
+	}
+	return int32(tick)
+}
+
+func fromDecimalString(value string) *big.Int {
+	result, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		panic("invalid decimal constant " + value)
+	}
+	return result
+}
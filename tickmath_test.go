@@ -0,0 +1,127 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+// Vectors from TickMath.spec.ts of the v3 core contracts and its snapshot
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	tests := []struct {
+		tick      int32
+		sqrtRatio string
+	}{
+		{MIN_TICK, "4295128739"},
+		{MIN_TICK + 1, "4295343490"},
+		{-738203, "7409801140451"},
+		{-500000, "1101692437043807371"},
+		{-250000, "295440463448801648376846"},
+		{-150000, "43836292794701720435367485"},
+		{-50000, "6504256538020985011912221507"},
+		{-5000, "61703726247759831737814779831"},
+		{-4000, "64867181785621769311890333195"},
+		{-3000, "68192822843687888778582228483"},
+		{-2500, "69919044979842180277688105136"},
+		{-1000, "75364347830767020784054125655"},
+		{-500, "77272108795590369356373805297"},
+		{-250, "78244023372248365697264290337"},
+		{-100, "78833030112140176575862854579"},
+		{-50, "79030349367926598376800521322"},
+		{0, "79228162514264337593543950336"},
+		{50, "79426470787362580746886972461"},
+		{100, "79625275426524748796330556128"},
+		{250, "80224679980005306637834519095"},
+		{500, "81233731461783161732293370115"},
+		{1000, "83290069058676223003182343270"},
+		{2500, "89776708723587163891445672585"},
+		{3000, "92049301871182272007977902845"},
+		{4000, "96768528593268422080558758223"},
+		{5000, "101729702841318637793976746270"},
+		{50000, "965075977353221155028623082916"},
+		{150000, "143194173941309278083010301478497"},
+		{250000, "21246587762933397357449903968194344"},
+		{500000, "5697689776495288729098254600827762987878"},
+		{738203, "847134979253254120489401328389043031315994541"},
+		{MAX_TICK - 1, "1461373636630004318706518188784493106690254656249"},
+		{MAX_TICK, "1461446703485210103287273052203988822378723970342"},
+	}
+
+	for _, test := range tests {
+		sqrtRatio, err := getSqrtRatioAtTick(test.tick)
+		if err != nil {
+			t.Errorf("tick %d: %v", test.tick, err)
+			continue
+		}
+		if sqrtRatio.String() != test.sqrtRatio {
+			t.Errorf("tick %d: sqrt ratio %v, want %v", test.tick, sqrtRatio, test.sqrtRatio)
+		}
+	}
+
+	if sqrtRatio, _ := getSqrtRatioAtTick(MIN_TICK); sqrtRatio.Cmp(MIN_SQRT_RATIO) != 0 {
+		t.Errorf("min tick: sqrt ratio %v, want MIN_SQRT_RATIO", sqrtRatio)
+	}
+	if sqrtRatio, _ := getSqrtRatioAtTick(MAX_TICK); sqrtRatio.Cmp(MAX_SQRT_RATIO) != 0 {
+		t.Errorf("max tick: sqrt ratio %v, want MAX_SQRT_RATIO", sqrtRatio)
+	}
+	for _, tick := range []int32{MIN_TICK - 1, MAX_TICK + 1} {
+		if _, err := getSqrtRatioAtTick(tick); err != errTickOutOfRange {
+			t.Errorf("tick %d: error %v, want %v", tick, err, errTickOutOfRange)
+		}
+	}
+}
+
+func TestGetTickAtSqrtRatio(t *testing.T) {
+	one := big.NewInt(1)
+
+	tests := []struct {
+		name      string
+		sqrtRatio *big.Int
+		tick      int32
+	}{
+		{"min sqrt ratio", MIN_SQRT_RATIO, MIN_TICK},
+		{"min sqrt ratio plus one", new(big.Int).Add(MIN_SQRT_RATIO, one), MIN_TICK},
+		{"ratio of min tick plus one", fromDecimalString("4295343490"), MIN_TICK + 1},
+		{"ratio of min tick plus one minus one", fromDecimalString("4295343489"), MIN_TICK},
+		{"price of 1", fromDecimalString("79228162514264337593543950336"), 0},
+		{"just below a price of 1", fromDecimalString("79228162514264337593543950335"), -1},
+		{"ratio of max tick minus one", fromDecimalString("1461373636630004318706518188784493106690254656249"), MAX_TICK - 1},
+		{"max sqrt ratio minus one", new(big.Int).Sub(MAX_SQRT_RATIO, one), MAX_TICK - 1},
+	}
+
+	for _, test := range tests {
+		tick, err := getTickAtSqrtRatio(test.sqrtRatio)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if tick != test.tick {
+			t.Errorf("%s: tick %d, want %d", test.name, tick, test.tick)
+		}
+	}
+
+	for _, sqrtRatio := range []*big.Int{new(big.Int).Sub(MIN_SQRT_RATIO, one), MAX_SQRT_RATIO} {
+		if _, err := getTickAtSqrtRatio(sqrtRatio); err != errSqrtRatioOutOfRange {
+			t.Errorf("sqrt ratio %v: error %v, want %v", sqrtRatio, err, errSqrtRatioOutOfRange)
+		}
+	}
+}
+
+// getTickAtSqrtRatio is the greatest tick whose sqrt ratio is at most the price, so the ratio of a tick maps back to the
+// tick and one less maps to the tick below
+func TestGetTickAtSqrtRatioRoundingBoundaries(t *testing.T) {
+	for _, tick := range []int32{MIN_TICK + 1, -500000, -50000, -3000, -60, -1, 1, 60, 3000, 50000, 500000, MAX_TICK - 1} {
+		sqrtRatio, err := getSqrtRatioAtTick(tick)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := getTickAtSqrtRatio(sqrtRatio)
+		if err != nil || got != tick {
+			t.Errorf("tick %d: tick at its sqrt ratio %d %v", tick, got, err)
+		}
+		got, err = getTickAtSqrtRatio(new(big.Int).Sub(sqrtRatio, big.NewInt(1)))
+		if err != nil || got != tick-1 {
+			t.Errorf("tick %d: tick just below its sqrt ratio %d %v, want %d", tick, got, err, tick-1)
+		}
+	}
+}
@@ -1,23 +1,20 @@
 package main
 
 import (
-	"context"
 	"fmt"
 	"math/big"
 	"quantumswap-cli/contracts/corev2"
 	"quantumswap-cli/contracts/v2swaprouter"
 	"time"
 
-	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
 	"github.com/quantumcoinproject/quantum-coin-go/common"
 	"github.com/quantumcoinproject/quantum-coin-go/core/types"
-	"github.com/quantumcoinproject/quantum-coin-go/crypto/cryptobase"
 	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
 	"github.com/quantumcoinproject/quantum-coin-go/params"
 )
 
 func createPair(tokenAaddress common.Address, tokenBaddress common.Address) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+	key, err := getSigningKey()
 	if err != nil {
 		return nil, err
 	}
@@ -27,24 +24,26 @@ func createPair(tokenAaddress common.Address, tokenBaddress common.Address) (*ty
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	fromAddress, err = getSigningAddress(key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := getNonce(client, fromAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	chainId, err := getChainId()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
 
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -52,6 +51,7 @@ func createPair(tokenAaddress common.Address, tokenBaddress common.Address) (*ty
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -59,12 +59,14 @@ func createPair(tokenAaddress common.Address, tokenBaddress common.Address) (*ty
 
 	contract, err := corev2.NewCorev2(v2CoreFactoryAddress, client)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
 	var tx *types.Transaction
 	tx, err = contract.CreatePair(txnOpts, tokenAaddress, tokenBaddress)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -102,8 +104,8 @@ func getPair(tokenAaddress common.Address, tokenBaddress common.Address) (*commo
 }
 
 func addLiquidityV2(tokenAaddress common.Address, tokenBaddress common.Address,
-	amountA int64, amountB int64, amountAmin int64, amountBmin int64) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+	amountA int64, amountB int64, amountAmin *big.Int, amountBmin *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
 	if err != nil {
 		return nil, err
 	}
@@ -116,24 +118,26 @@ func addLiquidityV2(tokenAaddress common.Address, tokenBaddress common.Address,
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	fromAddress, err = getSigningAddress(key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := getNonce(client, fromAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	chainId, err := getChainId()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
 
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -141,6 +145,7 @@ func addLiquidityV2(tokenAaddress common.Address, tokenBaddress common.Address,
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -148,27 +153,40 @@ func addLiquidityV2(tokenAaddress common.Address, tokenBaddress common.Address,
 
 	contract, err := v2swaprouter.NewV2swaprouter(v2SwapRouterContractAddress, client)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
 	var tx *types.Transaction
-	tx, err = contract.AddLiquidity(txnOpts, tokenAaddress, tokenBaddress, params.EtherToWei(big.NewInt(amountA)), params.EtherToWei(big.NewInt(amountB)),
-		params.EtherToWei(big.NewInt(amountAmin)), params.EtherToWei(big.NewInt(amountBmin)), fromAddress, big.NewInt(9999999999))
+	deadline, err := getDeadline()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
+	tx, err = contract.AddLiquidity(txnOpts, tokenAaddress, tokenBaddress, params.EtherToWei(big.NewInt(amountA)), params.EtherToWei(big.NewInt(amountB)),
+		amountAmin, amountBmin, fromAddress, deadline)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, checkDeadlineError(err)
+	}
+
 	fmt.Println("Your request to add liquidity v2 (mint) has been added to the queue for processing. Please check your account after 10 minutes.")
 	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
 	fmt.Println()
 
 	time.Sleep(1000 * time.Millisecond)
 
+	err = waitForDeadline(client, tx, deadline)
+	if err != nil {
+		return tx, err
+	}
+
 	return tx, nil
 }
 
-func swapExactTokensForTokens(tokenInAddress common.Address, tokenOutAddress common.Address, amountIn int64, amountOutMinimum int64) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+func swapExactTokensForTokens(tokenInAddress common.Address, tokenOutAddress common.Address, amountIn int64, amountOutMinimum *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
 	if err != nil {
 		return nil, err
 	}
@@ -178,24 +196,26 @@ func swapExactTokensForTokens(tokenInAddress common.Address, tokenOutAddress com
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	fromAddress, err = getSigningAddress(key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := getNonce(client, fromAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	chainId, err := getChainId()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
 
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -203,6 +223,7 @@ func swapExactTokensForTokens(tokenInAddress common.Address, tokenOutAddress com
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -210,21 +231,34 @@ func swapExactTokensForTokens(tokenInAddress common.Address, tokenOutAddress com
 
 	contract, err := v2swaprouter.NewV2swaprouter(v2SwapRouterContractAddress, client)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
 	var tx *types.Transaction
-	tx, err = contract.SwapExactTokensForTokens(txnOpts, params.EtherToWei(big.NewInt(amountIn)),
-		params.EtherToWei(big.NewInt(amountOutMinimum)), []common.Address{tokenInAddress, tokenOutAddress}, fromAddress, big.NewInt(9999999999))
+	deadline, err := getDeadline()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
+	tx, err = contract.SwapExactTokensForTokens(txnOpts, params.EtherToWei(big.NewInt(amountIn)),
+		amountOutMinimum, []common.Address{tokenInAddress, tokenOutAddress}, fromAddress, deadline)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, checkDeadlineError(err)
+	}
+
 	fmt.Println("Your request to swapExactTokensForTokens v2 has been added to the queue for processing. Please check your account after 10 minutes.")
 	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
 	fmt.Println()
 
 	time.Sleep(1000 * time.Millisecond)
 
+	err = waitForDeadline(client, tx, deadline)
+	if err != nil {
+		return tx, err
+	}
+
 	return tx, nil
 }
@@ -3,158 +3,106 @@ package main
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"quantumswap-cli/contracts/core"
 	"quantumswap-cli/contracts/nonfungiblepositionmanager"
+	"quantumswap-cli/contracts/quoterv2"
 	"quantumswap-cli/contracts/swaprouter"
 	"quantumswap-cli/contracts/v3pool"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi"
 	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
 	"github.com/quantumcoinproject/quantum-coin-go/common"
 	"github.com/quantumcoinproject/quantum-coin-go/core/types"
-	"github.com/quantumcoinproject/quantum-coin-go/crypto/cryptobase"
 	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
 	"github.com/quantumcoinproject/quantum-coin-go/params"
 )
 
-// getPriceFromTick converts a tick to a price using the formula: 1.0001^tick
+// getPriceFromTick converts a tick to a price (token1 per token0) using the formula: 1.0001^tick
 // tick is a signed 24-bit integer (int24 in Solidity, int32 in Go)
-// Uses big.Float for high precision calculations
-func getPriceFromTick(tick int32) *big.Float {
-	// Base: 1.0001
-	base := big.NewFloat(1.0001)
-
-	// If tick is 0, return 1.0
-	if tick == 0 {
-		return big.NewFloat(1.0)
-	}
-
-	// Use efficient exponentiation by squaring
-	result := big.NewFloat(1.0)
-	absTick := int32(tick)
-	if tick < 0 {
-		absTick = -tick
-	}
-
-	// Binary exponentiation (exponentiation by squaring)
-	currentPower := new(big.Float).Set(base)
-	for absTick > 0 {
-		if absTick&1 == 1 {
-			result.Mul(result, currentPower)
-		}
-		currentPower.Mul(currentPower, currentPower)
-		absTick >>= 1
-	}
-
-	// If tick was negative, take the reciprocal
-	if tick < 0 {
-		result.Quo(big.NewFloat(1.0), result)
+// The price is derived from getSqrtRatioAtTick, so it matches the price the contracts use for the tick exactly
+func getPriceFromTick(tick int32) (*big.Float, error) {
+	sqrtPriceX96, err := getSqrtRatioAtTick(tick)
+	if err != nil {
+		return nil, err
 	}
 
-	return result
-}
-
-// getPriceFromTickFloat64 is a simpler version using float64 (less precise but faster)
-func getPriceFromTickFloat64(tick int32) float64 {
-	return math.Pow(1.0001, float64(tick))
+	return getPriceFromSqrtPriceX96(sqrtPriceX96), nil
 }
 
-// getTickFromPrice converts a price to a tick using the formula: log(price) / log(1.0001)
-// price is a uint256 in Solidity, represented as *big.Float in Go
-func getTickFromPrice(price *big.Float) int32 {
-	// Calculate log(price) / log(1.0001)
-	// Using natural logarithm
-	priceFloat, _ := price.Float64()
-	logBase := math.Log(1.0001)
+// getPriceFromSqrtPriceX96 converts a Q64.96 square root price to a price using the formula: (sqrtPriceX96 / 2^96)^2
+func getPriceFromSqrtPriceX96(sqrtPriceX96 *big.Int) *big.Float {
+	priceX192 := new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96)
+	price := new(big.Rat).SetFrac(priceX192, Q192)
 
-	if priceFloat <= 0 {
-		return 0 // Invalid price
-	}
-
-	tickFloat := math.Log(priceFloat) / logBase
-
-	// Round to nearest integer (int24)
-	return int32(math.Round(tickFloat))
+	return new(big.Float).SetPrec(236).SetRat(price)
 }
 
-// getTickFromPriceFloat64 is a simpler version using float64
-func getTickFromPriceFloat64(price float64) int32 {
-	if price <= 0 {
-		return 0
+// getTickFromPrice returns the greatest tick whose price is less than or equal to price
+// price is the price of token1 in terms of token0. Prices outside the supported range are clamped to MIN_TICK and MAX_TICK
+func getTickFromPrice(price *big.Rat) int32 {
+	if price.Sign() <= 0 {
+		return MIN_TICK
 	}
 
-	logBase := math.Log(1.0001)
-	tickFloat := math.Log(price) / logBase
+	sqrtPriceX96 := getSqrtPriceX96FromPrice(price)
+	if sqrtPriceX96.Cmp(MIN_SQRT_RATIO) < 0 {
+		return MIN_TICK
+	}
+	if sqrtPriceX96.Cmp(MAX_SQRT_RATIO) >= 0 {
+		return MAX_TICK
+	}
 
-	return int32(math.Round(tickFloat))
+	tick, err := getTickAtSqrtRatio(sqrtPriceX96)
+	if err != nil {
+		return MIN_TICK
+	}
+	return tick
 }
 
-// calculateSqrtPriceX96 calculates the square root price scaled by 2^96
-// price: the price value
-// decimals0: number of decimals for token0
-// decimals1: number of decimals for token1
+// getSqrtPriceX96FromPrice calculates floor(sqrt(price) * 2^96)
+// price: the price of token1 in terms of token0
 // Returns: the square root price as a uint160 (represented as *big.Int)
-func calculateSqrtPriceX96(price *big.Int, decimals0, decimals1 uint8) *big.Int {
-	// Adjust for decimal differences between tokens
-	decimalsDiff := new(big.Int).Sub(
-		big.NewInt(int64(decimals0)),
-		big.NewInt(int64(decimals1)),
-	)
-
-	// Calculate 10^(decimals0 - decimals1)
-	ten := big.NewInt(10)
-	decimalsMultiplier := new(big.Int).Exp(ten, decimalsDiff, nil)
+func getSqrtPriceX96FromPrice(price *big.Rat) *big.Int {
+	// sqrt(num / den) * 2^96 = sqrt(num * 2^192 / den), and flooring before the square root does not change the result
+	priceX192 := new(big.Int).Mul(price.Num(), Q192)
+	priceX192.Quo(priceX192, price.Denom())
 
-	// adjustedPrice = price * 10^(decimals0 - decimals1)
-	adjustedPrice := new(big.Int).Mul(price, decimalsMultiplier)
-
-	// Calculate square root of (adjustedPrice * 10^18)
-	tenTo18 := new(big.Int).Exp(ten, big.NewInt(18), nil)
-	priceForSqrt := new(big.Int).Mul(adjustedPrice, tenTo18)
-
-	sqrtPrice := sqrt(priceForSqrt)
-
-	// Scale by 2^96
-	twoTo96 := new(big.Int).Exp(big.NewInt(2), big.NewInt(96), nil)
-	numerator := new(big.Int).Mul(sqrtPrice, twoTo96)
+	return priceX192.Sqrt(priceX192)
+}
 
-	// Divide by 10^9
-	tenTo9 := new(big.Int).Exp(ten, big.NewInt(9), nil)
-	result := new(big.Int).Div(numerator, tenTo9)
+// getRawPrice converts a price of token B per token A into a price in the smallest units of the tokens,
+// i.e. price * 10^tokenBdecimals / 10^tokenAdecimals
+func getRawPrice(price *big.Rat, tokenAdecimals uint8, tokenBdecimals uint8) *big.Rat {
+	ten := big.NewInt(10)
+	tokenAunit := new(big.Int).Exp(ten, big.NewInt(int64(tokenAdecimals)), nil)
+	tokenBunit := new(big.Int).Exp(ten, big.NewInt(int64(tokenBdecimals)), nil)
 
-	return result
+	rawPrice := new(big.Rat).Mul(price, new(big.Rat).SetInt(tokenBunit))
+	return rawPrice.Quo(rawPrice, new(big.Rat).SetInt(tokenAunit))
 }
 
-// sqrt calculates the square root of x using the Babylonian method
-func sqrt(x *big.Int) *big.Int {
-	if x.Sign() == 0 {
-		return big.NewInt(0)
+// getNearestUsableTick rounds a tick to the nearest multiple of tickSpacing that lies within MIN_TICK and MAX_TICK
+func getNearestUsableTick(tick int32, tickSpacing int32) int32 {
+	rounded := int32(math.Round(float64(tick)/float64(tickSpacing))) * tickSpacing
+	if rounded < MIN_TICK {
+		return rounded + tickSpacing
 	}
-
-	// z = (x + 1) / 2
-	one := big.NewInt(1)
-	z := new(big.Int).Add(x, one)
-	z.Div(z, big.NewInt(2))
-
-	y := new(big.Int).Set(x)
-
-	// Babylonian method: while z < y
-	for z.Cmp(y) < 0 {
-		y.Set(z)
-		// z = (x / z + z) / 2
-		temp := new(big.Int).Div(x, z)
-		temp.Add(temp, z)
-		z.Div(temp, big.NewInt(2))
+	if rounded > MAX_TICK {
+		return rounded - tickSpacing
 	}
-
-	return y
+	return rounded
 }
 
 func createPool(tokenAaddress common.Address, tokenBaddress common.Address, fee int64) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+	key, err := getSigningKey()
 	if err != nil {
 		return nil, err
 	}
@@ -164,24 +112,26 @@ func createPool(tokenAaddress common.Address, tokenBaddress common.Address, fee
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	fromAddress, err = getSigningAddress(key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := getNonce(client, fromAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	chainId, err := getChainId()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
 
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -189,6 +139,7 @@ func createPool(tokenAaddress common.Address, tokenBaddress common.Address, fee
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -196,12 +147,14 @@ func createPool(tokenAaddress common.Address, tokenBaddress common.Address, fee
 
 	contract, err := core.NewCore(v3CoreFactoryAddress, client)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
 	var tx *types.Transaction
 	tx, err = contract.CreatePool(txnOpts, tokenAaddress, tokenBaddress, big.NewInt(fee))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -238,8 +191,18 @@ func getPool(tokenAaddress common.Address, tokenBaddress common.Address, fee int
 	return &poolAddress, nil
 }
 
-func initializePool(poolAddress common.Address, price int64, tokenAdecimals uint8, tokenBdecimals uint8) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+func initializePool(poolAddress common.Address, price *big.Rat, tokenAdecimals uint8, tokenBdecimals uint8) (*types.Transaction, error) {
+	sqrtPriceX96 := getSqrtPriceX96FromPrice(getRawPrice(price, tokenAdecimals, tokenBdecimals))
+	if sqrtPriceX96.Cmp(MIN_SQRT_RATIO) < 0 || sqrtPriceX96.Cmp(MAX_SQRT_RATIO) >= 0 {
+		return nil, fmt.Errorf("price %s is outside the range supported by the pool (sqrtPriceX96 %s)", price.FloatString(18), sqrtPriceX96)
+	}
+	tick, err := getTickAtSqrtRatio(sqrtPriceX96)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("initializePool", "sqrtPriceX96", sqrtPriceX96, "tick", tick)
+
+	key, err := getSigningKey()
 	if err != nil {
 		return nil, err
 	}
@@ -249,24 +212,26 @@ func initializePool(poolAddress common.Address, price int64, tokenAdecimals uint
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	fromAddress, err = getSigningAddress(key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := getNonce(client, fromAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	chainId, err := getChainId()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
 
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -274,6 +239,7 @@ func initializePool(poolAddress common.Address, price int64, tokenAdecimals uint
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -281,14 +247,14 @@ func initializePool(poolAddress common.Address, price int64, tokenAdecimals uint
 
 	contract, err := v3pool.NewV3pool(poolAddress, client)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
-	sqrtPriceX96 := calculateSqrtPriceX96(big.NewInt(price), tokenAdecimals, tokenBdecimals)
-
 	var tx *types.Transaction
 	tx, err = contract.Initialize(txnOpts, sqrtPriceX96)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -302,8 +268,8 @@ func initializePool(poolAddress common.Address, price int64, tokenAdecimals uint
 }
 
 func addLiquidityV3(tokenAaddress common.Address, tokenBaddress common.Address, fee int64, tickLower int64, tickUpper int64,
-	amountA int64, amountB int64, amountAmin int64, amountBmin int64) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+	amountA int64, amountB int64, amountAmin *big.Int, amountBmin *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
 	if err != nil {
 		return nil, err
 	}
@@ -316,24 +282,26 @@ func addLiquidityV3(tokenAaddress common.Address, tokenBaddress common.Address,
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	fromAddress, err = getSigningAddress(key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := getNonce(client, fromAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	chainId, err := getChainId()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
 
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -341,6 +309,7 @@ func addLiquidityV3(tokenAaddress common.Address, tokenBaddress common.Address,
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -352,7 +321,11 @@ func addLiquidityV3(tokenAaddress common.Address, tokenBaddress common.Address,
 	mintParams.TickUpper = big.NewInt(tickUpper)
 	mintParams.Recipient = fromAddress //todo: correct check?
 	//mintParams.Recipient = nonFungiblePositionManagerAddress //todo: correct check?
-	mintParams.Deadline = big.NewInt(9999999999)
+	mintParams.Deadline, err = getDeadline()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
 
 	if bytes.Compare(tokenAaddress.Bytes(), tokenBaddress.Bytes()) < 0 {
 		fmt.Println("option A")
@@ -360,26 +333,28 @@ func addLiquidityV3(tokenAaddress common.Address, tokenBaddress common.Address,
 		mintParams.Token1 = tokenBaddress
 		mintParams.Amount0Desired = params.EtherToWei(big.NewInt(amountA))
 		mintParams.Amount1Desired = params.EtherToWei(big.NewInt(amountB))
-		mintParams.Amount0Min = params.EtherToWei(big.NewInt(amountAmin))
-		mintParams.Amount1Min = params.EtherToWei(big.NewInt(amountBmin))
+		mintParams.Amount0Min = amountAmin
+		mintParams.Amount1Min = amountBmin
 	} else {
 		fmt.Println("option B")
 		mintParams.Token0 = tokenBaddress
 		mintParams.Token1 = tokenAaddress
 		mintParams.Amount0Desired = params.EtherToWei(big.NewInt(amountB))
 		mintParams.Amount1Desired = params.EtherToWei(big.NewInt(amountA))
-		mintParams.Amount0Min = params.EtherToWei(big.NewInt(amountBmin))
-		mintParams.Amount1Min = params.EtherToWei(big.NewInt(amountAmin))
+		mintParams.Amount0Min = amountBmin
+		mintParams.Amount1Min = amountAmin
 	}
 
 	contract, err := nonfungiblepositionmanager.NewNonfungiblepositionmanager(nonFungiblePositionManagerAddress, client)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
 	var tx *types.Transaction
 	tx, err = contract.Mint(txnOpts, mintParams)
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -389,11 +364,64 @@ func addLiquidityV3(tokenAaddress common.Address, tokenBaddress common.Address,
 
 	time.Sleep(1000 * time.Millisecond)
 
+	err = waitForDeadline(client, tx, mintParams.Deadline)
+	if err != nil {
+		return tx, err
+	}
+
 	return tx, nil
 }
 
-func swapExactInputSingle(tokenInAddress common.Address, tokenOutAddress common.Address, fee int64, amountIn int64, amountOutMinimum int64) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+// getTokenPairDecimals reads the decimals of token A and token B from the node at DP_RAW_URL
+func getTokenPairDecimals(tokenAaddress common.Address, tokenBaddress common.Address) (uint8, uint8, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tokenAdecimals, err := getTokenDecimals(tokenAaddress, client)
+	if err != nil {
+		return 0, 0, err
+	}
+	tokenBdecimals, err := getTokenDecimals(tokenBaddress, client)
+	if err != nil {
+		return 0, 0, err
+	}
+	return tokenAdecimals, tokenBdecimals, nil
+}
+
+// getLaunchRange returns the initial sqrtPriceX96 and the tick range of launchpool. The prices are scaled from whole tokens to
+// the smallest units with the token decimals. The pool price is always token1 per token0, so the price and range are inverted
+// when token B sorts before token A.
+func getLaunchRange(tokenAaddress common.Address, tokenBaddress common.Address, price *big.Rat, priceLower *big.Rat, priceUpper *big.Rat,
+	tokenAdecimals uint8, tokenBdecimals uint8, tickSpacing int32) (*big.Int, int32, int32, error) {
+	price = getRawPrice(price, tokenAdecimals, tokenBdecimals)
+	priceLower = getRawPrice(priceLower, tokenAdecimals, tokenBdecimals)
+	priceUpper = getRawPrice(priceUpper, tokenAdecimals, tokenBdecimals)
+
+	price1Per0, price1Per0Lower, price1Per0Upper := price, priceLower, priceUpper
+	if bytes.Compare(tokenAaddress.Bytes(), tokenBaddress.Bytes()) > 0 {
+		price1Per0 = new(big.Rat).Inv(price)
+		price1Per0Lower = new(big.Rat).Inv(priceUpper)
+		price1Per0Upper = new(big.Rat).Inv(priceLower)
+	}
+
+	sqrtPriceX96 := getSqrtPriceX96FromPrice(price1Per0)
+	tickLower := getNearestUsableTick(getTickFromPrice(price1Per0Lower), tickSpacing)
+	tickUpper := getNearestUsableTick(getTickFromPrice(price1Per0Upper), tickSpacing)
+	if tickLower >= tickUpper {
+		return nil, 0, 0, fmt.Errorf("price range is too narrow for tick spacing %d", tickSpacing)
+	}
+
+	return sqrtPriceX96, tickLower, tickUpper, nil
+}
+
+// launchPool creates and initializes the pool if necessary and mints the first position in a single multicall transaction,
+// so that the pool cannot be initialized by someone else at a different price in between.
+// price, priceLower and priceUpper are prices of token B per token A.
+func launchPool(tokenAaddress common.Address, tokenBaddress common.Address, fee int64, price *big.Rat, priceLower *big.Rat, priceUpper *big.Rat,
+	amountA int64, amountB int64, amountAmin *big.Int, amountBmin *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
 	if err != nil {
 		return nil, err
 	}
@@ -403,94 +431,103 @@ func swapExactInputSingle(tokenInAddress common.Address, tokenOutAddress common.
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
+	fromAddress, err = getSigningAddress(key)
 
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	contract, err := nonfungiblepositionmanager.NewNonfungiblepositionmanager(nonFungiblePositionManagerAddress, client)
 	if err != nil {
 		return nil, err
 	}
 
-	chainId, err := getChainId()
+	factoryAddress, err := contract.Factory(nil)
 	if err != nil {
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
 
+	factory, err := core.NewCore(factoryAddress, client)
 	if err != nil {
 		return nil, err
 	}
 
-	txnOpts.From = fromAddress
-	txnOpts.Nonce = big.NewInt(int64(nonce))
-	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	tickSpacing, err := factory.FeeAmountTickSpacing(nil, big.NewInt(fee))
 	if err != nil {
 		return nil, err
 	}
+	if tickSpacing.Sign() == 0 {
+		return nil, fmt.Errorf("fee tier %d is not enabled in factory %s", fee, factoryAddress)
+	}
 
-	txnOpts.Value = big.NewInt(0)
+	var mintParams nonfungiblepositionmanager.INonfungiblePositionManagerMintParams
+	mintParams.Fee = big.NewInt(fee)
+	mintParams.Recipient = fromAddress
+	mintParams.Deadline, err = getDeadline()
+	if err != nil {
+		return nil, err
+	}
 
-	var swapParams swaprouter.IV3SwapRouterExactInputSingleParams
-	swapParams.TokenIn = tokenInAddress
-	swapParams.TokenOut = tokenOutAddress
-	swapParams.Fee = big.NewInt(fee)
-	swapParams.Recipient = fromAddress //todo: check if correct
-	swapParams.AmountIn = params.EtherToWei(big.NewInt(amountIn))
-	swapParams.AmountOutMinimum = params.EtherToWei(big.NewInt(amountOutMinimum))
-	swapParams.SqrtPriceLimitX96 = big.NewInt(0)
+	if bytes.Compare(tokenAaddress.Bytes(), tokenBaddress.Bytes()) < 0 {
+		mintParams.Token0 = tokenAaddress
+		mintParams.Token1 = tokenBaddress
+		mintParams.Amount0Desired = params.EtherToWei(big.NewInt(amountA))
+		mintParams.Amount1Desired = params.EtherToWei(big.NewInt(amountB))
+		mintParams.Amount0Min = amountAmin
+		mintParams.Amount1Min = amountBmin
+	} else {
+		mintParams.Token0 = tokenBaddress
+		mintParams.Token1 = tokenAaddress
+		mintParams.Amount0Desired = params.EtherToWei(big.NewInt(amountB))
+		mintParams.Amount1Desired = params.EtherToWei(big.NewInt(amountA))
+		mintParams.Amount0Min = amountBmin
+		mintParams.Amount1Min = amountAmin
+	}
 
-	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	tokenAdecimals, tokenBdecimals, err := getTokenPairDecimals(tokenAaddress, tokenBaddress)
 	if err != nil {
 		return nil, err
 	}
-
-	var tx *types.Transaction
-	tx, err = contract.ExactInputSingle(txnOpts, swapParams)
+	sqrtPriceX96, tickLower, tickUpper, err := getLaunchRange(tokenAaddress, tokenBaddress, price, priceLower, priceUpper, tokenAdecimals, tokenBdecimals,
+		int32(tickSpacing.Int64()))
 	if err != nil {
 		return nil, err
 	}
+	mintParams.TickLower = big.NewInt(int64(tickLower))
+	mintParams.TickUpper = big.NewInt(int64(tickUpper))
 
-	fmt.Println("Your request to swapExactSingle v3 has been added to the queue for processing. Please check your account after 10 minutes.")
-	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
-	fmt.Println()
-
-	time.Sleep(1000 * time.Millisecond)
-
-	return tx, nil
-}
+	fmt.Println("launchPool", "token0", mintParams.Token0, "token1", mintParams.Token1, "fee", fee, "tickSpacing", tickSpacing,
+		"sqrtPriceX96", sqrtPriceX96, "tickLower", tickLower, "tickUpper", tickUpper)
 
-func swapExactOutputSingle(tokenInAddress common.Address, tokenOutAddress common.Address, fee int64, amountOut int64, amountInMaximum int64) (*types.Transaction, error) {
-	key, err := GetKey(fromAddress.Hex())
+	parsed, err := nonfungiblepositionmanager.NonfungiblepositionmanagerMetaData.GetAbi()
 	if err != nil {
 		return nil, err
 	}
 
-	client, err := ethclient.Dial(rawURL)
+	createData, err := parsed.Pack("createAndInitializePoolIfNecessary", mintParams.Token0, mintParams.Token1, mintParams.Fee, sqrtPriceX96)
 	if err != nil {
 		return nil, err
 	}
 
-	fromAddress, err = cryptobase.SigAlg.PublicKeyToAddress(&key.PublicKey)
-
+	mintData, err := parsed.Pack("mint", mintParams)
 	if err != nil {
 		return nil, err
 	}
 
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	nonce, err := getNonce(client, fromAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	chainId, err := getChainId()
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
-	txnOpts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainId))
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
 
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
@@ -498,36 +535,1445 @@ func swapExactOutputSingle(tokenInAddress common.Address, tokenOutAddress common
 	txnOpts.Nonce = big.NewInt(int64(nonce))
 	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
 	txnOpts.Value = big.NewInt(0)
 
-	var swapParams swaprouter.IV3SwapRouterExactOutputSingleParams
-	swapParams.TokenIn = tokenInAddress
-	swapParams.TokenOut = tokenOutAddress
-	swapParams.Fee = big.NewInt(fee)
-	swapParams.Recipient = fromAddress //todo: check if correct
-	swapParams.AmountOut = params.EtherToWei(big.NewInt(amountOut))
-	swapParams.AmountInMaximum = params.EtherToWei(big.NewInt(amountInMaximum))
-	swapParams.SqrtPriceLimitX96 = big.NewInt(0)
-
-	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
-	if err != nil {
-		return nil, err
-	}
-
 	var tx *types.Transaction
-	tx, err = contract.ExactOutputSingle(txnOpts, swapParams)
+	tx, err = contract.Multicall(txnOpts, [][]byte{createData, mintData})
 	if err != nil {
+		releaseNonce(fromAddress, nonce)
 		return nil, err
 	}
 
-	fmt.Println("Your request to swapExactOutputSingle v3 has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("Your request to launch pool v3 (create, initialize and mint) has been added to the queue for processing. Please check your account after 10 minutes.")
 	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
 	fmt.Println()
 
 	time.Sleep(1000 * time.Millisecond)
 
+	err = waitForDeadline(client, tx, mintParams.Deadline)
+	if err != nil {
+		return tx, err
+	}
+
 	return tx, nil
 }
+
+// getDepositAmounts computes the liquidity minted for a deposit of amount of token0 (isToken0) or token1 into the range tickLower to tickUpper,
+// and the amounts of token0 and token1 that the pool pulls for that liquidity (rounded up, as the pool does).
+func getDepositAmounts(sqrtPriceX96 *big.Int, currentTick int32, tickLower int32, tickUpper int32, amount *big.Int, isToken0 bool) (*big.Int, *big.Int, *big.Int, error) {
+	sqrtRatioAX96, err := getSqrtRatioAtTick(tickLower)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sqrtRatioBX96, err := getSqrtRatioAtTick(tickUpper)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var liquidity *big.Int
+	if isToken0 {
+		if currentTick >= tickUpper {
+			return nil, nil, nil, errors.New("current price is above the range, only token1 can be deposited")
+		}
+		lowerSqrtRatio := sqrtRatioAX96
+		if currentTick >= tickLower {
+			lowerSqrtRatio = sqrtPriceX96
+		}
+		liquidity, err = getLiquidityForAmount0(lowerSqrtRatio, sqrtRatioBX96, amount)
+	} else {
+		if currentTick < tickLower {
+			return nil, nil, nil, errors.New("current price is below the range, only token0 can be deposited")
+		}
+		upperSqrtRatio := sqrtRatioBX96
+		if currentTick < tickUpper {
+			upperSqrtRatio = sqrtPriceX96
+		}
+		liquidity, err = getLiquidityForAmount1(sqrtRatioAX96, upperSqrtRatio, amount)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Same branches as the pool uses when minting a position
+	amount0 := big.NewInt(0)
+	amount1 := big.NewInt(0)
+	if currentTick < tickLower {
+		amount0, err = getAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity, true)
+	} else if currentTick < tickUpper {
+		amount0, err = getAmount0Delta(sqrtPriceX96, sqrtRatioBX96, liquidity, true)
+		if err == nil {
+			amount1, err = getAmount1Delta(sqrtRatioAX96, sqrtPriceX96, liquidity, true)
+		}
+	} else {
+		amount1, err = getAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity, true)
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return liquidity, amount0, amount1, nil
+}
+
+// applySlippage returns floor(amount * (100 - slippagePercent) / 100)
+func applySlippage(amount *big.Int, slippagePercent *big.Rat) *big.Int {
+	factor := new(big.Rat).Sub(big.NewRat(100, 1), slippagePercent)
+	factor.Quo(factor, big.NewRat(100, 1))
+	result := new(big.Rat).Mul(new(big.Rat).SetInt(amount), factor)
+
+	return new(big.Int).Quo(result.Num(), result.Denom())
+}
+
+// formatWei formats an amount in wei as a decimal string of tokens with 18 decimals
+func formatWei(amount *big.Int) string {
+	return new(big.Rat).SetFrac(amount, params.EtherToWei(big.NewInt(1))).FloatString(18)
+}
+
+func getV3Deposit(poolAddress common.Address, tickLower int64, tickUpper int64, tokenAddress common.Address, amount *big.Int,
+	slippagePercent *big.Rat) (*big.Int, *big.Int, *big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	contract, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	token0, err := contract.Token0(nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	token1, err := contract.Token1(nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if tokenAddress.IsEqualTo(token0) == false && tokenAddress.IsEqualTo(token1) == false {
+		return nil, nil, nil, fmt.Errorf("token %s is not part of pool %s", tokenAddress, poolAddress)
+	}
+
+	tickSpacing, err := contract.TickSpacing(nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if tickLower >= tickUpper || tickLower < MIN_TICK || tickUpper > MAX_TICK {
+		return nil, nil, nil, fmt.Errorf("invalid tick range %d to %d", tickLower, tickUpper)
+	}
+	if tickLower%tickSpacing.Int64() != 0 || tickUpper%tickSpacing.Int64() != 0 {
+		return nil, nil, nil, fmt.Errorf("ticks should be multiples of the pool's tick spacing %d", tickSpacing)
+	}
+
+	slot0, err := contract.Slot0(nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	liquidity, amount0, amount1, err := getDepositAmounts(slot0.SqrtPriceX96, int32(slot0.Tick.Int64()), int32(tickLower), int32(tickUpper),
+		amount, tokenAddress.IsEqualTo(token0))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	fmt.Println("v3 deposit", "pool", poolAddress, "tickLower", tickLower, "tickUpper", tickUpper, "currentTick", slot0.Tick, "sqrtPriceX96", slot0.SqrtPriceX96)
+	fmt.Println("token0", token0, "amount0", amount0, "(", formatWei(amount0), ")", "amount0Min", applySlippage(amount0, slippagePercent))
+	fmt.Println("token1", token1, "amount1", amount1, "(", formatWei(amount1), ")", "amount1Min", applySlippage(amount1, slippagePercent))
+	fmt.Println("liquidity", liquidity, "slippage %", slippagePercent.FloatString(2))
+	fmt.Println()
+
+	return liquidity, amount0, amount1, nil
+}
+
+// getHumanPriceFromTick converts a tick of a pool to the price of token B per token A in whole tokens
+func getHumanPriceFromTick(tick int32, tokenAisToken0 bool, tokenAdecimals uint8, tokenBdecimals uint8) (*big.Float, error) {
+	sqrtPriceX96, err := getSqrtRatioAtTick(tick)
+	if err != nil {
+		return nil, err
+	}
+
+	price1Per0 := new(big.Rat).SetFrac(new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96), Q192)
+	rawPrice := price1Per0
+	if tokenAisToken0 == false {
+		rawPrice = new(big.Rat).Inv(price1Per0)
+	}
+
+	// getRawPrice with the decimals swapped undoes the decimals adjustment
+	return new(big.Float).SetPrec(236).SetRat(getRawPrice(rawPrice, tokenBdecimals, tokenAdecimals)), nil
+}
+
+// resolveTickBound converts a range bound of addliquidityv3 to a pool tick. Accepted forms are:
+// an integer tick (-600), a price of token B per token A (price:1.25), a percentage around the current price (-10%, +10%),
+// or the ends of the full range (min, max)
+func resolveTickBound(bound string, currentPrice *big.Rat, tokenAisToken0 bool, tokenAdecimals uint8, tokenBdecimals uint8) (int32, error) {
+	if bound == "min" {
+		return MIN_TICK, nil
+	}
+	if bound == "max" {
+		return MAX_TICK, nil
+	}
+
+	var price *big.Rat
+	if strings.HasPrefix(bound, "price:") {
+		parsed, err := ParseBigRat(strings.TrimPrefix(bound, "price:"))
+		if err != nil {
+			return 0, err
+		}
+		price = parsed
+	} else if strings.HasSuffix(bound, "%") {
+		percent, err := ParseBigRat(strings.TrimPrefix(strings.TrimSuffix(bound, "%"), "+"))
+		if err != nil {
+			return 0, err
+		}
+		factor := new(big.Rat).Add(big.NewRat(1, 1), new(big.Rat).Quo(percent, big.NewRat(100, 1)))
+		price = factor.Mul(factor, currentPrice)
+	} else {
+		tick, err := strconv.ParseInt(bound, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid range bound %s", bound)
+		}
+		return clampTick(tick), nil
+	}
+
+	if price.Sign() <= 0 {
+		return 0, fmt.Errorf("range bound %s results in a price that is not positive", bound)
+	}
+
+	rawPrice := getRawPrice(price, tokenAdecimals, tokenBdecimals)
+	if tokenAisToken0 == false {
+		rawPrice.Inv(rawPrice)
+	}
+	return getTickFromPrice(rawPrice), nil
+}
+
+// resolveV3TickRange converts the range bounds of addliquidityv3 to ticks of the pool of tokenA, tokenB and fee,
+// aligned to the tick spacing of the pool, and prints the resulting price range
+func resolveV3TickRange(tokenAaddress common.Address, tokenBaddress common.Address, fee int64, lowerBound string, upperBound string) (int32, int32, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	contract, err := nonfungiblepositionmanager.NewNonfungiblepositionmanager(nonFungiblePositionManagerAddress, client)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	factoryAddress, err := contract.Factory(nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	factory, err := core.NewCore(factoryAddress, client)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	poolAddress, err := factory.GetPool(nil, tokenAaddress, tokenBaddress, big.NewInt(fee))
+	if err != nil {
+		return 0, 0, err
+	}
+	if poolAddress.IsEqualTo(common.Address{}) {
+		return 0, 0, errors.New("pool does not exist, create and initialize the pool first")
+	}
+
+	pool, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tickSpacing, err := pool.TickSpacing(nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	slot0, err := pool.Slot0(nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tokenAdecimals, err := getTokenDecimals(tokenAaddress, client)
+	if err != nil {
+		return 0, 0, err
+	}
+	tokenBdecimals, err := getTokenDecimals(tokenBaddress, client)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tokenAisToken0 := bytes.Compare(tokenAaddress.Bytes(), tokenBaddress.Bytes()) < 0
+	currentPrice1Per0 := new(big.Rat).SetFrac(new(big.Int).Mul(slot0.SqrtPriceX96, slot0.SqrtPriceX96), Q192)
+	if tokenAisToken0 == false {
+		currentPrice1Per0.Inv(currentPrice1Per0)
+	}
+	currentPrice := getRawPrice(currentPrice1Per0, tokenBdecimals, tokenAdecimals)
+
+	tickLower, err := resolveTickBound(lowerBound, currentPrice, tokenAisToken0, tokenAdecimals, tokenBdecimals)
+	if err != nil {
+		return 0, 0, err
+	}
+	tickUpper, err := resolveTickBound(upperBound, currentPrice, tokenAisToken0, tokenAdecimals, tokenBdecimals)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	alignedTickLower, alignedTickUpper := alignTickRange(tickLower, tickUpper, int32(tickSpacing.Int64()))
+
+	priceAtTickLower, err := getHumanPriceFromTick(alignedTickLower, tokenAisToken0, tokenAdecimals, tokenBdecimals)
+	if err != nil {
+		return 0, 0, err
+	}
+	priceAtTickUpper, err := getHumanPriceFromTick(alignedTickUpper, tokenAisToken0, tokenAdecimals, tokenBdecimals)
+	if err != nil {
+		return 0, 0, err
+	}
+	minPrice, maxPrice := priceAtTickLower, priceAtTickUpper
+	if tokenAisToken0 == false {
+		minPrice, maxPrice = priceAtTickUpper, priceAtTickLower
+	}
+
+	fmt.Println("v3 poolAddress", poolAddress, "tickSpacing", tickSpacing, "currentTick", slot0.Tick)
+	fmt.Println("Current price (token B per token A)", currentPrice.FloatString(18))
+	fmt.Println("tickLower", alignedTickLower, "tickUpper", alignedTickUpper)
+	fmt.Println("Price range (token B per token A)", minPrice.Text('g', 18), "to", maxPrice.Text('g', 18))
+	fmt.Println()
+
+	return alignedTickLower, alignedTickUpper, nil
+}
+
+// alignTickRange orders the ticks and aligns them to the tick spacing, keeping the range at least one tick spacing wide
+func alignTickRange(tickLower int32, tickUpper int32, spacing int32) (int32, int32) {
+	// The lower price of token B per token A is the upper tick when token B is token0
+	if tickLower > tickUpper {
+		tickLower, tickUpper = tickUpper, tickLower
+	}
+
+	alignedTickLower := getNearestUsableTick(tickLower, spacing)
+	alignedTickUpper := getNearestUsableTick(tickUpper, spacing)
+	if alignedTickLower == alignedTickUpper {
+		if alignedTickUpper+spacing <= MAX_TICK {
+			alignedTickUpper = alignedTickUpper + spacing
+		} else {
+			alignedTickLower = alignedTickLower - spacing
+		}
+	}
+	if alignedTickLower != tickLower || alignedTickUpper != tickUpper {
+		fmt.Println("Ticks", tickLower, tickUpper, "aligned to tick spacing", spacing, "as", alignedTickLower, alignedTickUpper)
+	}
+
+	return alignedTickLower, alignedTickUpper
+}
+
+func swapExactInputSingle(tokenInAddress common.Address, tokenOutAddress common.Address, fee int64, amountIn int64, amountOutMinimum *big.Int,
+	limitPrice *big.Rat) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var swapParams swaprouter.IV3SwapRouterExactInputSingleParams
+	swapParams.TokenIn = tokenInAddress
+	swapParams.TokenOut = tokenOutAddress
+	swapParams.Fee = big.NewInt(fee)
+	swapParams.Recipient = fromAddress //todo: check if correct
+	swapParams.AmountIn = params.EtherToWei(big.NewInt(amountIn))
+	swapParams.AmountOutMinimum = amountOutMinimum
+	swapParams.SqrtPriceLimitX96, err = getSqrtPriceLimitX96(client, tokenInAddress, tokenOutAddress, fee, limitPrice)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	tx, deadline, err := sendSwapWithDeadline(contract, txnOpts, "exactInputSingle", swapParams)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to swapExactSingle v3 has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	if limitPrice != nil {
+		err = reportSwapFill(client, tx, deadline, tokenInAddress, tokenOutAddress, swapParams.AmountIn, true)
+	} else {
+		err = waitForDeadline(client, tx, deadline)
+	}
+	if err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
+
+func swapExactOutputSingle(tokenInAddress common.Address, tokenOutAddress common.Address, fee int64, amountOut int64, amountInMaximum *big.Int,
+	limitPrice *big.Rat) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var swapParams swaprouter.IV3SwapRouterExactOutputSingleParams
+	swapParams.TokenIn = tokenInAddress
+	swapParams.TokenOut = tokenOutAddress
+	swapParams.Fee = big.NewInt(fee)
+	swapParams.Recipient = fromAddress //todo: check if correct
+	swapParams.AmountOut = params.EtherToWei(big.NewInt(amountOut))
+	swapParams.AmountInMaximum = amountInMaximum
+	swapParams.SqrtPriceLimitX96, err = getSqrtPriceLimitX96(client, tokenInAddress, tokenOutAddress, fee, limitPrice)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	tx, deadline, err := sendSwapWithDeadline(contract, txnOpts, "exactOutputSingle", swapParams)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to swapExactOutputSingle v3 has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	if limitPrice != nil {
+		err = reportSwapFill(client, tx, deadline, tokenInAddress, tokenOutAddress, swapParams.AmountOut, false)
+	} else {
+		err = waitForDeadline(client, tx, deadline)
+	}
+	if err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
+
+// getV3Route resolves a route such as TOKEN_A:3000:WQ:500:TOKEN_B and verifies that the pool of every hop exists
+func getV3Route(route string) ([]common.Address, []int64, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wqAddress, err := contract.WETH9(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, fees, err := parseRoute(route, wqAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factoryAddress, err := contract.Factory(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = getRoutePools(client, factoryAddress, tokens, fees)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, fees, nil
+}
+
+// getRoutePools returns the pool of every hop of the route, failing if any of them has not been created
+func getRoutePools(client *ethclient.Client, factoryAddress common.Address, tokens []common.Address, fees []int64) ([]common.Address, error) {
+	factory, err := core.NewCore(factoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]common.Address, len(fees))
+	for i, fee := range fees {
+		poolAddress, err := factory.GetPool(nil, tokens[i], tokens[i+1], big.NewInt(fee))
+		if err != nil {
+			return nil, err
+		}
+		if poolAddress.IsEqualTo(common.Address{}) {
+			return nil, fmt.Errorf("hop %d: no pool exists for %s and %s with fee %d", i+1, tokens[i], tokens[i+1], fee)
+		}
+		fmt.Println("hop", i+1, "tokenIn", tokens[i], "tokenOut", tokens[i+1], "fee", fee, "pool", poolAddress)
+		pools[i] = poolAddress
+	}
+
+	return pools, nil
+}
+
+// quoteV3Route simulates exactInput (exactInput = true) or exactOutput on the router using a call from fromAddress,
+// returning the amount out or the amount in respectively. The call fails unless fromAddress holds the input token and has approved the router,
+// so it is only used when QUOTER_V2_CONTRACT_ADDRESS is not set.
+func quoteV3Route(tokens []common.Address, fees []int64, amount *big.Int, exactInput bool) (*big.Int, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := encodePath(tokens, fees, !exactInput)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := &swaprouter.SwaprouterRaw{Contract: contract}
+	var out []interface{}
+	if exactInput {
+		var swapParams swaprouter.IV3SwapRouterExactInputParams
+		swapParams.Path = path
+		swapParams.Recipient = fromAddress
+		swapParams.AmountIn = amount
+		swapParams.AmountOutMinimum = big.NewInt(0)
+		err = raw.Call(&bind.CallOpts{From: fromAddress}, &out, "exactInput", swapParams)
+	} else {
+		var swapParams swaprouter.IV3SwapRouterExactOutputParams
+		swapParams.Path = path
+		swapParams.Recipient = fromAddress
+		swapParams.AmountOut = amount
+		swapParams.AmountInMaximum = MAX_UINT256
+		err = raw.Call(&bind.CallOpts{From: fromAddress}, &out, "exactOutput", swapParams)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("quote failed (does FROM_ADDRESS hold the input token and has it approved the router?): %w", err)
+	}
+
+	return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+}
+
+func swapExactInput(tokens []common.Address, fees []int64, amountIn int64, amountOutMinimum *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	path, err := encodePath(tokens, fees, false)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	var swapParams swaprouter.IV3SwapRouterExactInputParams
+	swapParams.Path = path
+	swapParams.Recipient = fromAddress
+	swapParams.AmountIn = params.EtherToWei(big.NewInt(amountIn))
+	swapParams.AmountOutMinimum = amountOutMinimum
+
+	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	tx, deadline, err := sendSwapWithDeadline(contract, txnOpts, "exactInput", swapParams)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to swapExactInput v3 has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	err = waitForDeadline(client, tx, deadline)
+	if err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
+
+func swapExactOutput(tokens []common.Address, fees []int64, amountOut int64, amountInMaximum *big.Int) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	// exactOutput swaps along the path in reverse, from the output token to the input token
+	path, err := encodePath(tokens, fees, true)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	var swapParams swaprouter.IV3SwapRouterExactOutputParams
+	swapParams.Path = path
+	swapParams.Recipient = fromAddress
+	swapParams.AmountOut = params.EtherToWei(big.NewInt(amountOut))
+	swapParams.AmountInMaximum = amountInMaximum
+
+	contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	tx, deadline, err := sendSwapWithDeadline(contract, txnOpts, "exactOutput", swapParams)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to swapExactOutput v3 has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	err = waitForDeadline(client, tx, deadline)
+	if err != nil {
+		return tx, err
+	}
+
+	return tx, nil
+}
+
+// v3Quote is the result of a QuoterV2 quote. The lists are ordered by the hops of the route, from the input token to the output token
+type v3Quote struct {
+	Amount                      *big.Int
+	SqrtPriceX96AfterList       []*big.Int
+	InitializedTicksCrossedList []uint32
+	GasEstimate                 *big.Int
+}
+
+// quoteV3 quotes a route with QuoterV2. Single hop routes use quoteExactInputSingle / quoteExactOutputSingle, which stop at
+// sqrtPriceLimitX96 like the swap does (nil for no limit); multi hop quotes have no limit.
+// The quoter functions are not view functions (they revert with the result of the swap), so they are simulated with a call
+func quoteV3(client *ethclient.Client, opts *bind.CallOpts, tokens []common.Address, fees []int64, amount *big.Int, exactInput bool,
+	sqrtPriceLimitX96 *big.Int) (*v3Quote, error) {
+	if sqrtPriceLimitX96 == nil {
+		sqrtPriceLimitX96 = big.NewInt(0)
+	} else if len(fees) != 1 {
+		return nil, errors.New("a price limit can only be quoted for a single hop")
+	}
+
+	contract, err := quoterv2.NewQuoterv2(quoterv2ContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+	raw := &quoterv2.Quoterv2Raw{Contract: contract}
+
+	var out []interface{}
+	if len(fees) == 1 {
+		if exactInput {
+			var quoteParams quoterv2.IQuoterV2QuoteExactInputSingleParams
+			quoteParams.TokenIn = tokens[0]
+			quoteParams.TokenOut = tokens[1]
+			quoteParams.AmountIn = amount
+			quoteParams.Fee = big.NewInt(fees[0])
+			quoteParams.SqrtPriceLimitX96 = sqrtPriceLimitX96
+			err = raw.Call(opts, &out, "quoteExactInputSingle", quoteParams)
+		} else {
+			var quoteParams quoterv2.IQuoterV2QuoteExactOutputSingleParams
+			quoteParams.TokenIn = tokens[0]
+			quoteParams.TokenOut = tokens[1]
+			quoteParams.Amount = amount
+			quoteParams.Fee = big.NewInt(fees[0])
+			quoteParams.SqrtPriceLimitX96 = sqrtPriceLimitX96
+			err = raw.Call(opts, &out, "quoteExactOutputSingle", quoteParams)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return &v3Quote{
+			Amount:                      *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+			SqrtPriceX96AfterList:       []*big.Int{*abi.ConvertType(out[1], new(*big.Int)).(**big.Int)},
+			InitializedTicksCrossedList: []uint32{*abi.ConvertType(out[2], new(uint32)).(*uint32)},
+			GasEstimate:                 *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+		}, nil
+	}
+
+	path, err := encodePath(tokens, fees, !exactInput)
+	if err != nil {
+		return nil, err
+	}
+	if exactInput {
+		err = raw.Call(opts, &out, "quoteExactInput", path, amount)
+	} else {
+		err = raw.Call(opts, &out, "quoteExactOutput", path, amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &v3Quote{
+		Amount:                      *abi.ConvertType(out[0], new(*big.Int)).(**big.Int),
+		SqrtPriceX96AfterList:       *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int),
+		InitializedTicksCrossedList: *abi.ConvertType(out[2], new([]uint32)).(*[]uint32),
+		GasEstimate:                 *abi.ConvertType(out[3], new(*big.Int)).(**big.Int),
+	}
+
+	// exactOutput walks the path from the output token to the input token
+	if exactInput == false {
+		for i, j := 0, len(fees)-1; i < j; i, j = i+1, j-1 {
+			quote.SqrtPriceX96AfterList[i], quote.SqrtPriceX96AfterList[j] = quote.SqrtPriceX96AfterList[j], quote.SqrtPriceX96AfterList[i]
+			quote.InitializedTicksCrossedList[i], quote.InitializedTicksCrossedList[j] = quote.InitializedTicksCrossedList[j], quote.InitializedTicksCrossedList[i]
+		}
+	}
+
+	return quote, nil
+}
+
+// getV3Quote quotes AMOUNT (in wei) along the route and prints the amount, the price of every pool after the swap,
+// the initialized ticks crossed, the gas estimate and the price impact against the current prices of the pools (slot0).
+// A single hop route can be quoted up to limitPrice (nil for no limit), as the swap with LIMIT_PRICE would fill it
+func getV3Quote(route string, amount *big.Int, exactInput bool, limitPrice *big.Rat) (*v3Quote, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := quoterv2.NewQuoterv2(quoterv2ContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	wqAddress, err := contract.WETH9(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, fees, err := parseRoute(route, wqAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	factoryAddress, err := contract.Factory(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pools, err := getRoutePools(client, factoryAddress, tokens, fees)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqrtPriceLimitX96 *big.Int
+	if limitPrice != nil {
+		if len(fees) != 1 {
+			return nil, errors.New("a limit price can only be quoted for a single hop")
+		}
+		sqrtPriceLimitX96, err = getSqrtPriceLimitX96(client, tokens[0], tokens[1], fees[0], limitPrice)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	quote, err := quoteV3(client, nil, tokens, fees, amount, exactInput, sqrtPriceLimitX96)
+	if err != nil {
+		return nil, err
+	}
+	if sqrtPriceLimitX96 != nil && quote.SqrtPriceX96AfterList[0].Cmp(sqrtPriceLimitX96) == 0 {
+		fmt.Println("The quote reaches the limit price, the swap would only be partially filled")
+	}
+
+	// mid price of the route in raw units of the output token per raw unit of the input token
+	midPrice := big.NewRat(1, 1)
+	for i, poolAddress := range pools {
+		pool, err := v3pool.NewV3pool(poolAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		slot0, err := pool.Slot0(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		priceBefore := new(big.Rat).SetFrac(new(big.Int).Mul(slot0.SqrtPriceX96, slot0.SqrtPriceX96), Q192)
+		sqrtPriceAfter := quote.SqrtPriceX96AfterList[i]
+		priceAfter := new(big.Rat).SetFrac(new(big.Int).Mul(sqrtPriceAfter, sqrtPriceAfter), Q192)
+		priceChange := new(big.Rat).Quo(priceAfter, priceBefore)
+		priceChange.Sub(priceChange, big.NewRat(1, 1)).Mul(priceChange, big.NewRat(100, 1))
+
+		if bytes.Compare(tokens[i].Bytes(), tokens[i+1].Bytes()) < 0 {
+			midPrice.Mul(midPrice, priceBefore)
+		} else {
+			midPrice.Quo(midPrice, priceBefore)
+		}
+
+		fmt.Println("hop", i+1, "sqrtPriceX96Before", slot0.SqrtPriceX96, "sqrtPriceX96After", sqrtPriceAfter,
+			"poolPriceChange", priceChange.FloatString(4)+"%", "initializedTicksCrossed", quote.InitializedTicksCrossedList[i])
+	}
+
+	amountIn, amountOut := amount, quote.Amount
+	if exactInput {
+		fmt.Println("Quoted amount out", formatWei(quote.Amount))
+	} else {
+		amountIn, amountOut = quote.Amount, amount
+		fmt.Println("Quoted amount in", formatWei(quote.Amount))
+	}
+	fmt.Println("Gas estimate", quote.GasEstimate)
+
+	if amountIn.Sign() > 0 && midPrice.Sign() > 0 {
+		executionPrice := new(big.Rat).SetFrac(amountOut, amountIn)
+		priceImpact := new(big.Rat).Quo(executionPrice, midPrice)
+		priceImpact.Sub(big.NewRat(1, 1), priceImpact).Mul(priceImpact, big.NewRat(100, 1))
+		fmt.Println("Price impact (including fees)", priceImpact.FloatString(4)+"%")
+	}
+
+	return quote, nil
+}
+
+// simulateV3Swaps loads the state of the pool once and simulates swapping each of the amounts (in wei) of tokenAddress in (exactInput)
+// or of the other token out of the pool. When compareWithQuoter is set, every result is checked against QuoterV2 at the same block
+func simulateV3Swaps(poolAddress common.Address, tokenAddress common.Address, amounts []*big.Int, exactInput bool, compareWithQuoter bool) error {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return err
+	}
+
+	state, err := loadV3PoolState(client, poolAddress)
+	if err != nil {
+		return err
+	}
+
+	var zeroForOne bool
+	tokenOutAddress := state.Token0
+	if tokenAddress.IsEqualTo(state.Token0) {
+		zeroForOne = true
+		tokenOutAddress = state.Token1
+	} else if tokenAddress.IsEqualTo(state.Token1) == false {
+		return errors.New("token is not in the pool")
+	}
+
+	fmt.Println("Loaded pool", poolAddress, "at block", state.BlockNumber, "fee", state.Fee, "tickSpacing", state.TickSpacing,
+		"sqrtPriceX96", state.SqrtPriceX96, "tick", state.Tick, "liquidity", state.Liquidity)
+
+	for _, amount := range amounts {
+		amountSpecified := new(big.Int).Set(amount)
+		if exactInput == false {
+			amountSpecified.Neg(amountSpecified)
+		}
+
+		result, err := state.simulateSwap(zeroForOne, amountSpecified, nil)
+		if err != nil {
+			return err
+		}
+
+		amountIn, amountOut := result.Amount0, new(big.Int).Neg(result.Amount1)
+		if zeroForOne == false {
+			amountIn, amountOut = result.Amount1, new(big.Int).Neg(result.Amount0)
+		}
+		fmt.Println()
+		fmt.Println("amountIn", formatWei(amountIn), "amountOut", formatWei(amountOut), "fee", formatWei(result.FeeAmount))
+		fmt.Println("sqrtPriceX96After", result.SqrtPriceX96, "tickAfter", result.Tick, "priceAfter", getPriceFromSqrtPriceX96(result.SqrtPriceX96).Text('g', 18),
+			"liquidityAfter", result.Liquidity, "initializedTicksCrossed", result.InitializedTicksCrossed)
+		if (exactInput && amountIn.Cmp(amount) != 0) || (exactInput == false && amountOut.Cmp(amount) != 0) {
+			fmt.Println("Partial fill: the pool does not have enough liquidity for the full amount")
+		}
+
+		if compareWithQuoter == false {
+			continue
+		}
+		quote, err := quoteV3(client, &bind.CallOpts{BlockNumber: state.BlockNumber}, []common.Address{tokenAddress, tokenOutAddress},
+			[]int64{state.Fee}, amount, exactInput, nil)
+		if err != nil {
+			fmt.Println("QuoterV2 error", err)
+			continue
+		}
+		simulated := amountOut
+		if exactInput == false {
+			simulated = amountIn
+		}
+		if quote.Amount.Cmp(simulated) == 0 && quote.SqrtPriceX96AfterList[0].Cmp(result.SqrtPriceX96) == 0 {
+			fmt.Println("QuoterV2 parity OK")
+		} else {
+			fmt.Println("QuoterV2 MISMATCH", "quotedAmount", quote.Amount, "simulatedAmount", simulated,
+				"quotedSqrtPriceX96After", quote.SqrtPriceX96AfterList[0], "simulatedSqrtPriceX96After", result.SqrtPriceX96)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Tick bitmap words read", len(state.TickBitmap), "initialized ticks read", len(state.LiquidityNet))
+
+	return nil
+}
+
+// getOldestObservationAge returns how many seconds ago the oldest observation stored by the pool was written.
+// The observations buffer is circular, the oldest entry is the one after the current index unless the buffer has not been filled yet
+func getOldestObservationAge(client *ethclient.Client, contract *v3pool.V3pool) (uint32, error) {
+	slot0, err := contract.Slot0(nil)
+	if err != nil {
+		return 0, err
+	}
+	if slot0.ObservationCardinality == 0 || slot0.SqrtPriceX96.Sign() == 0 {
+		return 0, errors.New("the pool is not initialized, it has no observations")
+	}
+
+	header, err := client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	oldest, err := contract.Observations(nil, big.NewInt(int64((slot0.ObservationIndex+1)%slot0.ObservationCardinality)))
+	if err != nil {
+		return 0, err
+	}
+	if oldest.Initialized == false {
+		oldest, err = contract.Observations(nil, big.NewInt(0))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return uint32(header.Time) - oldest.BlockTimestamp, nil
+}
+
+// getTwap returns the time weighted average tick and the harmonic mean liquidity of the pool over the last secondsAgo seconds
+// Port of OracleLibrary.consult of the v3 periphery contracts
+func getTwap(poolAddress common.Address, secondsAgo uint32) (int32, *big.Int, error) {
+	if secondsAgo == 0 {
+		return 0, nil, errors.New("window should be greater than 0 seconds")
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	contract, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	oldestAge, err := getOldestObservationAge(client, contract)
+	if err != nil {
+		return 0, nil, err
+	}
+	if secondsAgo > oldestAge {
+		slot0, err := contract.Slot0(nil)
+		if err != nil {
+			return 0, nil, err
+		}
+		return 0, nil, fmt.Errorf("window of %d seconds is older than the oldest stored observation (%d seconds ago, observation cardinality %d, cardinality next %d). "+
+			"Use a shorter window or grow the buffer with increasecardinality", secondsAgo, oldestAge, slot0.ObservationCardinality, slot0.ObservationCardinalityNext)
+	}
+
+	observation, err := contract.Observe(nil, []uint32{secondsAgo, 0})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	tickCumulativesDelta := new(big.Int).Sub(observation.TickCumulatives[1], observation.TickCumulatives[0])
+	secondsPerLiquidityCumulativesDelta := new(big.Int).Sub(observation.SecondsPerLiquidityCumulativeX128s[1], observation.SecondsPerLiquidityCumulativeX128s[0])
+	// the contract computes the delta as an uint160, wrapping around on overflow
+	secondsPerLiquidityCumulativesDelta.Mod(secondsPerLiquidityCumulativesDelta, new(big.Int).Lsh(big.NewInt(1), 160))
+
+	window := big.NewInt(int64(secondsAgo))
+	arithmeticMeanTick, remainder := new(big.Int).QuoRem(tickCumulativesDelta, window, new(big.Int))
+	// always round to negative infinity
+	if tickCumulativesDelta.Sign() < 0 && remainder.Sign() != 0 {
+		arithmeticMeanTick.Sub(arithmeticMeanTick, big.NewInt(1))
+	}
+
+	// we are multiplying here instead of shifting to ensure that harmonicMeanLiquidity doesn't overflow uint128
+	harmonicMeanLiquidity := MAX_UINT128
+	if secondsPerLiquidityCumulativesDelta.Sign() > 0 {
+		secondsAgoX160 := new(big.Int).Mul(window, MAX_UINT160)
+		harmonicMeanLiquidity = secondsAgoX160.Quo(secondsAgoX160, secondsPerLiquidityCumulativesDelta.Lsh(secondsPerLiquidityCumulativesDelta, 32))
+		if harmonicMeanLiquidity.Cmp(MAX_UINT128) > 0 {
+			harmonicMeanLiquidity = MAX_UINT128
+		}
+	}
+
+	return int32(arithmeticMeanTick.Int64()), harmonicMeanLiquidity, nil
+}
+
+func increaseObservationCardinality(poolAddress common.Address, observationCardinalityNext uint16) (*types.Transaction, error) {
+	key, err := getSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fromAddress, err = getSigningAddress(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	slot0, err := contract.Slot0(nil)
+	if err != nil {
+		return nil, err
+	}
+	if observationCardinalityNext <= slot0.ObservationCardinalityNext {
+		return nil, fmt.Errorf("observation cardinality next is already %d", slot0.ObservationCardinalityNext)
+	}
+
+	nonce, err := getNonce(client, fromAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	chainId, err := getChainId()
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+	txnOpts, err := newTransactor(key, big.NewInt(chainId))
+
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.From = fromAddress
+	txnOpts.Nonce = big.NewInt(int64(nonce))
+	txnOpts.GasLimit, err = getGasLimit(uint64(6000000))
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	txnOpts.Value = big.NewInt(0)
+
+	var tx *types.Transaction
+	tx, err = contract.IncreaseObservationCardinalityNext(txnOpts, observationCardinalityNext)
+	if err != nil {
+		releaseNonce(fromAddress, nonce)
+		return nil, err
+	}
+
+	fmt.Println("Your request to increase observation cardinality has been added to the queue for processing. Please check your account after 10 minutes.")
+	fmt.Println("The transaction hash for tracking this request is: ", tx.Hash())
+	fmt.Println()
+
+	time.Sleep(1000 * time.Millisecond)
+
+	return tx, nil
+}
+
+// getSqrtPriceLimitX96 converts a limit price, given as the amount of tokenOut received per tokenIn, into the sqrtPriceX96 the pool
+// stops swapping at. Swapping token0 for token1 lowers the price of the pool, so the limit is the price itself; swapping token1 for token0
+// raises it, so the limit is the inverse. A nil limitPrice means no limit (0)
+func getSqrtPriceLimitX96(client *ethclient.Client, tokenInAddress common.Address, tokenOutAddress common.Address, fee int64, limitPrice *big.Rat) (*big.Int, error) {
+	if limitPrice == nil {
+		return big.NewInt(0), nil
+	}
+	if limitPrice.Sign() <= 0 {
+		return nil, errors.New("limit price should be greater than 0")
+	}
+
+	router, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+	if err != nil {
+		return nil, err
+	}
+	factoryAddress, err := router.Factory(nil)
+	if err != nil {
+		return nil, err
+	}
+	factory, err := core.NewCore(factoryAddress, client)
+	if err != nil {
+		return nil, err
+	}
+	poolAddress, err := factory.GetPool(nil, tokenInAddress, tokenOutAddress, big.NewInt(fee))
+	if err != nil {
+		return nil, err
+	}
+	if poolAddress.IsEqualTo(common.Address{}) {
+		return nil, errors.New("pool does not exist")
+	}
+	pool, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, err
+	}
+	slot0, err := pool.Slot0(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenInDecimals, err := getTokenDecimals(tokenInAddress, client)
+	if err != nil {
+		return nil, err
+	}
+	tokenOutDecimals, err := getTokenDecimals(tokenOutAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	zeroForOne := bytes.Compare(tokenInAddress.Bytes(), tokenOutAddress.Bytes()) < 0
+	rawPrice := getRawPrice(limitPrice, tokenInDecimals, tokenOutDecimals)
+	if zeroForOne == false {
+		rawPrice.Inv(rawPrice)
+	}
+	sqrtPriceLimitX96 := getSqrtPriceX96FromPrice(rawPrice)
+
+	if zeroForOne {
+		if sqrtPriceLimitX96.Cmp(slot0.SqrtPriceX96) >= 0 {
+			return nil, fmt.Errorf("limit price %s should be lower than the current price %s", limitPrice.FloatString(18),
+				getHumanPriceFromSqrtPriceX96(slot0.SqrtPriceX96, zeroForOne, tokenInDecimals, tokenOutDecimals).FloatString(18))
+		}
+		if sqrtPriceLimitX96.Cmp(MIN_SQRT_RATIO) <= 0 {
+			return nil, errors.New("limit price is below the minimum price of the pool")
+		}
+	} else {
+		if sqrtPriceLimitX96.Cmp(slot0.SqrtPriceX96) <= 0 {
+			return nil, fmt.Errorf("limit price %s should be lower than the current price %s", limitPrice.FloatString(18),
+				getHumanPriceFromSqrtPriceX96(slot0.SqrtPriceX96, zeroForOne, tokenInDecimals, tokenOutDecimals).FloatString(18))
+		}
+		if sqrtPriceLimitX96.Cmp(MAX_SQRT_RATIO) >= 0 {
+			return nil, errors.New("limit price is below the minimum price of the pool")
+		}
+	}
+
+	fmt.Println("Limit price", limitPrice.FloatString(18), "sqrtPriceLimitX96", sqrtPriceLimitX96, "current sqrtPriceX96", slot0.SqrtPriceX96)
+
+	return sqrtPriceLimitX96, nil
+}
+
+// getHumanPriceFromSqrtPriceX96 returns the price of the pool as the amount of tokenOut per tokenIn in whole tokens
+func getHumanPriceFromSqrtPriceX96(sqrtPriceX96 *big.Int, zeroForOne bool, tokenInDecimals uint8, tokenOutDecimals uint8) *big.Rat {
+	rawPrice := new(big.Rat).SetFrac(new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96), Q192)
+	if zeroForOne == false {
+		rawPrice.Inv(rawPrice)
+	}
+	// getRawPrice scales by 10^tokenOutDecimals / 10^tokenInDecimals, scaling back swaps the decimals
+	return getRawPrice(rawPrice, tokenOutDecimals, tokenInDecimals)
+}
+
+// reportSwapFill waits for the swap to be mined and reports the amounts of the Swap event of the pool,
+// so that a swap stopped by its price limit shows how much of the requested amount was filled
+func reportSwapFill(client *ethclient.Client, tx *types.Transaction, deadline *big.Int, tokenInAddress common.Address, tokenOutAddress common.Address,
+	requestedAmount *big.Int, exactInput bool) error {
+	fmt.Println("Waiting for the transaction to be mined to report the filled amount...")
+	receipt, err := waitMinedUntilDeadline(client, tx, deadline)
+	if err != nil || receipt == nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return getFailedTransactionError(client, tx, receipt)
+	}
+
+	poolAbi, err := abi.JSON(strings.NewReader(v3pool.V3poolABI))
+	if err != nil {
+		return err
+	}
+	filterer, err := v3pool.NewV3poolFilterer(common.Address{}, client)
+	if err != nil {
+		return err
+	}
+
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Topics[0] != poolAbi.Events["Swap"].ID {
+			continue
+		}
+		swap, err := filterer.ParseSwap(*log)
+		if err != nil {
+			return err
+		}
+
+		amountIn, amountOut := swap.Amount0, new(big.Int).Neg(swap.Amount1)
+		if bytes.Compare(tokenInAddress.Bytes(), tokenOutAddress.Bytes()) > 0 {
+			amountIn, amountOut = swap.Amount1, new(big.Int).Neg(swap.Amount0)
+		}
+
+		filled := amountOut
+		if exactInput {
+			filled = amountIn
+		}
+		fmt.Println("amountIn", formatWei(amountIn), "amountOut", formatWei(amountOut), "sqrtPriceX96After", swap.SqrtPriceX96, "tickAfter", swap.Tick)
+		if filled.Cmp(requestedAmount) < 0 {
+			fmt.Println("The limit price was reached, filled", formatWei(filled), "of", formatWei(requestedAmount))
+		} else {
+			fmt.Println("The swap was filled completely")
+		}
+		return nil
+	}
+
+	return errors.New("swap event not found in the transaction receipt")
+}
+
+// getV3FactoryAddress returns the v3 factory, either configured directly or read from the position manager or the swap router
+func getV3FactoryAddress(client *ethclient.Client) (common.Address, error) {
+	if v3CoreFactoryAddress.IsEqualTo(common.Address{}) == false {
+		return v3CoreFactoryAddress, nil
+	}
+
+	if nonFungiblePositionManagerAddress.IsEqualTo(common.Address{}) == false {
+		contract, err := nonfungiblepositionmanager.NewNonfungiblepositionmanager(nonFungiblePositionManagerAddress, client)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return contract.Factory(nil)
+	}
+
+	if v3SwapRouterContractAddress.IsEqualTo(common.Address{}) == false {
+		contract, err := swaprouter.NewSwaprouter(v3SwapRouterContractAddress, client)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return contract.Factory(nil)
+	}
+
+	return common.Address{}, errors.New("factory address is not known")
+}
+
+// checkFeeTier returns the tick spacing of the fee tier, failing if the factory has not enabled it
+func checkFeeTier(fee int64) (int32, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return 0, err
+	}
+
+	factoryAddress, err := getV3FactoryAddress(client)
+	if err != nil {
+		return 0, err
+	}
+
+	factory, err := core.NewCore(factoryAddress, client)
+	if err != nil {
+		return 0, err
+	}
+
+	tickSpacing, err := factory.FeeAmountTickSpacing(nil, big.NewInt(fee))
+	if err != nil {
+		return 0, err
+	}
+	if tickSpacing.Sign() == 0 {
+		return 0, fmt.Errorf("fee tier %d is not enabled in factory %s", fee, factoryAddress)
+	}
+
+	return int32(tickSpacing.Int64()), nil
+}
+
+// getFeeTiers returns the fee tiers enabled in the factory and their tick spacing, sorted by fee.
+// Fee tiers are found from the FeeAmountEnabled events of the factory, together with the usual tiers (100, 500, 3000 and 10000),
+// in case the node does not serve old logs. A fee tier can not be disabled once it has been enabled
+func getFeeTiers() ([]int64, []int32, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factory, err := core.NewCore(v3CoreFactoryAddress, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := map[int64]bool{ONE_BP_FEE: true, 500: true, 3000: true, 10000: true}
+
+	iterator, err := factory.FilterFeeAmountEnabled(&bind.FilterOpts{Start: 0}, nil, nil)
+	if err != nil {
+		fmt.Println("Could not read FeeAmountEnabled events, only checking the default fee tiers", err)
+	} else {
+		for iterator.Next() {
+			candidates[iterator.Event.Fee.Int64()] = true
+		}
+		if iterator.Error() != nil {
+			fmt.Println("Could not read all FeeAmountEnabled events", iterator.Error())
+		}
+		iterator.Close()
+	}
+
+	fees := make([]int64, 0, len(candidates))
+	for fee := range candidates {
+		fees = append(fees, fee)
+	}
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+
+	enabledFees := make([]int64, 0, len(fees))
+	tickSpacings := make([]int32, 0, len(fees))
+	for _, fee := range fees {
+		tickSpacing, err := factory.FeeAmountTickSpacing(nil, big.NewInt(fee))
+		if err != nil {
+			return nil, nil, err
+		}
+		if tickSpacing.Sign() == 0 {
+			continue
+		}
+		enabledFees = append(enabledFees, fee)
+		tickSpacings = append(tickSpacings, int32(tickSpacing.Int64()))
+	}
+
+	return enabledFees, tickSpacings, nil
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"quantumswap-cli/contracts/v3pool"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Off-chain replica of UniswapV3Pool.swap. Slot0 and the liquidity are loaded once at a single block. The tick bitmap is read
+// word by word at the same block as the swaps reach it, together with the liquidityNet of the initialized ticks of the word,
+// so only the words between the current price and where the swaps stop are read, and each of them only once.
+// Protocol fees are not modelled, they do not change the swap amounts.
+
+var errSwapAmountZero = errors.New("swap amount should not be zero (AS)")
+var errSwapPriceLimit = errors.New("sqrt price limit is on the wrong side of the current price or out of range (SPL)")
+
+type v3PoolState struct {
+	Address      common.Address
+	BlockNumber  *big.Int
+	Token0       common.Address
+	Token1       common.Address
+	Fee          int64
+	TickSpacing  int32
+	SqrtPriceX96 *big.Int
+	Tick         int32
+	Liquidity    *big.Int
+	TickBitmap   map[int16]*big.Int // the words read so far, a state without a contract treats missing words as empty
+	LiquidityNet map[int32]*big.Int
+
+	contract *v3pool.V3pool
+	opts     *bind.CallOpts
+}
+
+type v3SwapResult struct {
+	Amount0                 *big.Int // positive when paid into the pool, negative when received from it
+	Amount1                 *big.Int
+	FeeAmount               *big.Int // in the input token
+	SqrtPriceX96            *big.Int
+	Tick                    int32
+	Liquidity               *big.Int
+	InitializedTicksCrossed uint32
+}
+
+// loadV3PoolState reads the swap state of the pool at the latest block, the tick bitmap is read later as the swaps need it
+func loadV3PoolState(client *ethclient.Client, poolAddress common.Address) (*v3PoolState, error) {
+	blockNumber, err := client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{BlockNumber: new(big.Int).SetUint64(blockNumber)}
+
+	contract, err := v3pool.NewV3pool(poolAddress, client)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &v3PoolState{
+		Address:      poolAddress,
+		BlockNumber:  opts.BlockNumber,
+		TickBitmap:   make(map[int16]*big.Int),
+		LiquidityNet: make(map[int32]*big.Int),
+		contract:     contract,
+		opts:         opts,
+	}
+
+	state.Token0, err = contract.Token0(opts)
+	if err != nil {
+		return nil, err
+	}
+	state.Token1, err = contract.Token1(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := contract.Fee(opts)
+	if err != nil {
+		return nil, err
+	}
+	state.Fee = fee.Int64()
+
+	tickSpacing, err := contract.TickSpacing(opts)
+	if err != nil {
+		return nil, err
+	}
+	state.TickSpacing = int32(tickSpacing.Int64())
+
+	slot0, err := contract.Slot0(opts)
+	if err != nil {
+		return nil, err
+	}
+	if slot0.SqrtPriceX96.Sign() == 0 {
+		return nil, errors.New("pool has not been initialized")
+	}
+	state.SqrtPriceX96 = slot0.SqrtPriceX96
+	state.Tick = int32(slot0.Tick.Int64())
+
+	state.Liquidity, err = contract.Liquidity(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// compressTick divides the tick by the tick spacing, rounding towards negative infinity
+func compressTick(tick int32, tickSpacing int32) int32 {
+	compressed := tick / tickSpacing
+	if tick < 0 && tick%tickSpacing != 0 {
+		compressed--
+	}
+	return compressed
+}
+
+// tickBitmapPosition computes the position in the mapping where the initialized bit for a tick lives
+func tickBitmapPosition(tick int32) (int16, uint8) {
+	return int16(tick >> 8), uint8(tick & 0xff)
+}
+
+// nextInitializedTickWithinOneWord returns the next initialized tick contained in the same word (or adjacent word) as the tick
+// that is either to the left (less than or equal to) or right (greater than) of the given tick.
+// Port of TickBitmap.nextInitializedTickWithinOneWord of the v3 core contracts
+func (state *v3PoolState) nextInitializedTickWithinOneWord(tick int32, lte bool) (int32, bool, error) {
+	compressed := compressTick(tick, state.TickSpacing)
+
+	if lte {
+		wordPos, bitPos := tickBitmapPosition(compressed)
+		// all the 1s at or to the right of the current bitPos
+		mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bitPos)+1), big.NewInt(1))
+		word, err := state.word(wordPos)
+		if err != nil {
+			return 0, false, err
+		}
+		masked := mask.And(mask, word)
+
+		// if there are no initialized ticks to the right of or at the current tick, return rightmost in the word
+		if masked.Sign() != 0 {
+			return (compressed - int32(bitPos) + int32(masked.BitLen()-1)) * state.TickSpacing, true, nil
+		}
+		return (compressed - int32(bitPos)) * state.TickSpacing, false, nil
+	}
+
+	// start from the word of the next tick, since the current tick state doesn't matter
+	wordPos, bitPos := tickBitmapPosition(compressed + 1)
+	// all the 1s at or to the left of the bitPos
+	mask := new(big.Int).Lsh(new(big.Int).Rsh(MAX_UINT256, uint(bitPos)), uint(bitPos))
+	word, err := state.word(wordPos)
+	if err != nil {
+		return 0, false, err
+	}
+	masked := mask.And(mask, word)
+
+	// if there are no initialized ticks to the left of the current tick, return leftmost in the word
+	if masked.Sign() != 0 {
+		return (compressed + 1 + int32(masked.TrailingZeroBits()) - int32(bitPos)) * state.TickSpacing, true, nil
+	}
+	return (compressed + 1 + int32(255-bitPos)) * state.TickSpacing, false, nil
+}
+
+// word returns the tick bitmap word at wordPos. The first time a word is needed it is read from the pool, along with the
+// liquidityNet of each initialized tick in it
+func (state *v3PoolState) word(wordPos int16) (*big.Int, error) {
+	word, ok := state.TickBitmap[wordPos]
+	if ok {
+		return word, nil
+	}
+	if state.contract == nil {
+		return big.NewInt(0), nil
+	}
+
+	word, err := state.contract.TickBitmap(state.opts, wordPos)
+	if err != nil {
+		return nil, err
+	}
+
+	for bitPos := 0; bitPos < 256; bitPos++ {
+		if word.Bit(bitPos) == 0 {
+			continue
+		}
+		tick := (int32(wordPos)*256 + int32(bitPos)) * state.TickSpacing
+		info, err := state.contract.Ticks(state.opts, big.NewInt(int64(tick)))
+		if err != nil {
+			return nil, err
+		}
+		state.LiquidityNet[tick] = info.LiquidityNet
+	}
+	state.TickBitmap[wordPos] = word
+
+	return word, nil
+}
+
+// simulateSwap swaps token0 for token1 (zeroForOne) or token1 for token0 against the loaded state without modifying
+// its price or liquidity, the tick bitmap words read on the way are kept for the next swaps.
+// amountSpecified is positive for exact input and negative for exact output. A nil sqrtPriceLimitX96 means no limit.
+// When the limit (or the end of the liquidity) is reached before amountSpecified is filled, the result is a partial fill
+func (state *v3PoolState) simulateSwap(zeroForOne bool, amountSpecified *big.Int, sqrtPriceLimitX96 *big.Int) (*v3SwapResult, error) {
+	if amountSpecified.Sign() == 0 {
+		return nil, errSwapAmountZero
+	}
+
+	if sqrtPriceLimitX96 == nil {
+		if zeroForOne {
+			sqrtPriceLimitX96 = new(big.Int).Add(MIN_SQRT_RATIO, big.NewInt(1))
+		} else {
+			sqrtPriceLimitX96 = new(big.Int).Sub(MAX_SQRT_RATIO, big.NewInt(1))
+		}
+	}
+	if zeroForOne {
+		if sqrtPriceLimitX96.Cmp(state.SqrtPriceX96) >= 0 || sqrtPriceLimitX96.Cmp(MIN_SQRT_RATIO) <= 0 {
+			return nil, errSwapPriceLimit
+		}
+	} else {
+		if sqrtPriceLimitX96.Cmp(state.SqrtPriceX96) <= 0 || sqrtPriceLimitX96.Cmp(MAX_SQRT_RATIO) >= 0 {
+			return nil, errSwapPriceLimit
+		}
+	}
+
+	exactInput := amountSpecified.Sign() > 0
+
+	amountSpecifiedRemaining := new(big.Int).Set(amountSpecified)
+	amountCalculated := big.NewInt(0)
+	sqrtPriceX96 := new(big.Int).Set(state.SqrtPriceX96)
+	tick := state.Tick
+	liquidity := new(big.Int).Set(state.Liquidity)
+	feeAmount := big.NewInt(0)
+	var initializedTicksCrossed uint32
+
+	// continue swapping as long as we haven't used the entire input/output and haven't reached the price limit
+	for amountSpecifiedRemaining.Sign() != 0 && sqrtPriceX96.Cmp(sqrtPriceLimitX96) != 0 {
+		sqrtPriceStartX96 := sqrtPriceX96
+
+		tickNext, initialized, err := state.nextInitializedTickWithinOneWord(tick, zeroForOne)
+		if err != nil {
+			return nil, err
+		}
+
+		// ensure that we do not overshoot the min/max tick, as the tick bitmap is not aware of these bounds
+		if tickNext < MIN_TICK {
+			tickNext = MIN_TICK
+		} else if tickNext > MAX_TICK {
+			tickNext = MAX_TICK
+		}
+
+		sqrtPriceNextX96, err := getSqrtRatioAtTick(tickNext)
+		if err != nil {
+			return nil, err
+		}
+
+		sqrtPriceTargetX96 := sqrtPriceNextX96
+		if (zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) < 0) || (zeroForOne == false && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) > 0) {
+			sqrtPriceTargetX96 = sqrtPriceLimitX96
+		}
+
+		// compute values to swap to the target tick, price limit, or point where input/output amount is exhausted
+		var stepAmountIn, stepAmountOut, stepFeeAmount *big.Int
+		sqrtPriceX96, stepAmountIn, stepAmountOut, stepFeeAmount, err = computeSwapStep(sqrtPriceX96, sqrtPriceTargetX96, liquidity,
+			amountSpecifiedRemaining, state.Fee)
+		if err != nil {
+			return nil, err
+		}
+		feeAmount.Add(feeAmount, stepFeeAmount)
+
+		if exactInput {
+			amountSpecifiedRemaining.Sub(amountSpecifiedRemaining, new(big.Int).Add(stepAmountIn, stepFeeAmount))
+			amountCalculated.Sub(amountCalculated, stepAmountOut)
+		} else {
+			amountSpecifiedRemaining.Add(amountSpecifiedRemaining, stepAmountOut)
+			amountCalculated.Add(amountCalculated, new(big.Int).Add(stepAmountIn, stepFeeAmount))
+		}
+
+		// shift tick if we reached the next price
+		if sqrtPriceX96.Cmp(sqrtPriceNextX96) == 0 {
+			// if the tick is initialized, run the tick transition
+			if initialized {
+				liquidityNet, ok := state.LiquidityNet[tickNext]
+				if ok == false {
+					liquidityNet = big.NewInt(0)
+				}
+				// if we're moving leftward, we interpret liquidityNet as the opposite sign
+				if zeroForOne {
+					liquidity.Sub(liquidity, liquidityNet)
+				} else {
+					liquidity.Add(liquidity, liquidityNet)
+				}
+				if liquidity.Sign() < 0 || liquidity.Cmp(MAX_UINT128) > 0 {
+					return nil, errLiquidityOverflow
+				}
+				initializedTicksCrossed++
+			}
+
+			if zeroForOne {
+				tick = tickNext - 1
+			} else {
+				tick = tickNext
+			}
+		} else if sqrtPriceX96.Cmp(sqrtPriceStartX96) != 0 {
+			// recompute unless we're on a lower tick boundary (i.e. already transitioned ticks), and haven't moved
+			tick, err = getTickAtSqrtRatio(sqrtPriceX96)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &v3SwapResult{
+		FeeAmount:               feeAmount,
+		SqrtPriceX96:            sqrtPriceX96,
+		Tick:                    tick,
+		Liquidity:               liquidity,
+		InitializedTicksCrossed: initializedTicksCrossed,
+	}
+	if zeroForOne == exactInput {
+		result.Amount0 = new(big.Int).Sub(amountSpecified, amountSpecifiedRemaining)
+		result.Amount1 = amountCalculated
+	} else {
+		result.Amount0 = amountCalculated
+		result.Amount1 = new(big.Int).Sub(amountSpecified, amountSpecifiedRemaining)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/quantumcoinproject/quantum-coin-go/accounts/abi/bind"
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// newTestPoolState returns an in-memory pool state (no contract) with the given ticks initialized
+func newTestPoolState(tickSpacing int32, ticks ...int32) *v3PoolState {
+	state := &v3PoolState{
+		TickSpacing:  tickSpacing,
+		TickBitmap:   make(map[int16]*big.Int),
+		LiquidityNet: make(map[int32]*big.Int),
+	}
+	for _, tick := range ticks {
+		wordPos, bitPos := tickBitmapPosition(compressTick(tick, tickSpacing))
+		word, ok := state.TickBitmap[wordPos]
+		if ok == false {
+			word = big.NewInt(0)
+			state.TickBitmap[wordPos] = word
+		}
+		word.SetBit(word, int(bitPos), 1)
+	}
+	return state
+}
+
+// Vectors from TickBitmap.spec.ts of the v3 core contracts
+func TestNextInitializedTickWithinOneWord(t *testing.T) {
+	state := newTestPoolState(1, -200, -55, -4, 70, 78, 84, 139, 240, 535)
+
+	tests := []struct {
+		tick        int32
+		lte         bool
+		next        int32
+		initialized bool
+	}{
+		// lte = false
+		{78, false, 84, true},                   // returns tick to right if at initialized tick
+		{-55, false, -4, true},                  // returns tick to right if at initialized tick
+		{77, false, 78, true},                   // returns the tick directly to the right
+		{-56, false, -55, true},                 // returns the tick directly to the right
+		{255, false, 511, false},                // returns the next words initialized tick if on the right boundary
+		{-257, false, -200, true},               // returns the next words initialized tick if on the right boundary
+		{508, false, 511, false},                // does not exceed boundary
+		{383, false, 511, false},                // skips half word
+		{239, false, 240, true},                 // returns the next initialized tick in the same word
+		{-201, false, -200, true},               // returns the next initialized tick from a negative tick
+		{535, false, 767, false},                // returns the end of the word after the last initialized tick
+		{78, true, 78, true},                    // returns same tick if initialized
+		{79, true, 78, true},                    // returns tick directly to the left of input tick if not initialized
+		{258, true, 256, false},                 // will not exceed the word boundary
+		{256, true, 256, false},                 // at the word boundary
+		{72, true, 70, true},                    // word boundary less 1 (next initialized tick in next word)
+		{-257, true, -512, false},               // word boundary
+		{1023, true, 768, false},                // entire empty word
+		{900, true, 768, false},                 // halfway through empty word
+		{-56, true, -200, true},                 // returns the next initialized tick to the left in a negative word
+		{int32(MIN_TICK), true, -887296, false}, // the bitmap is not aware of MIN_TICK, the swap clamps it
+	}
+
+	for _, test := range tests {
+		next, initialized, err := state.nextInitializedTickWithinOneWord(test.tick, test.lte)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != test.next || initialized != test.initialized {
+			t.Errorf("tick %d lte %v: got %d %v, want %d %v", test.tick, test.lte, next, initialized, test.next, test.initialized)
+		}
+	}
+
+	// with a tick spacing, the ticks are compressed before looking them up
+	spaced := newTestPoolState(60, -120, 0, 60, 600)
+	for _, test := range []struct {
+		tick        int32
+		lte         bool
+		next        int32
+		initialized bool
+	}{
+		{0, false, 60, true},
+		{1, false, 60, true},
+		{60, false, 600, true},
+		{-1, true, -120, true},
+		{59, true, 0, true},
+		{-121, true, -15360, false},
+	} {
+		next, initialized, err := spaced.nextInitializedTickWithinOneWord(test.tick, test.lte)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next != test.next || initialized != test.initialized {
+			t.Errorf("spacing 60 tick %d lte %v: got %d %v, want %d %v", test.tick, test.lte, next, initialized, test.next, test.initialized)
+		}
+	}
+}
+
+// newTestPosition returns an in-memory pool at price 1 (tick 0) with a single position from tickLower to tickUpper
+func newTestPosition(tickSpacing int32, tickLower int32, tickUpper int32, liquidity *big.Int, fee int64) *v3PoolState {
+	state := newTestPoolState(tickSpacing, tickLower, tickUpper)
+	state.Fee = fee
+	state.SqrtPriceX96 = fromDecimalString("79228162514264337593543950336")
+	state.Tick = 0
+	state.Liquidity = new(big.Int).Set(liquidity)
+	state.LiquidityNet[tickLower] = new(big.Int).Set(liquidity)
+	state.LiquidityNet[tickUpper] = new(big.Int).Neg(liquidity)
+	return state
+}
+
+func TestSimulateSwapWithinOneTick(t *testing.T) {
+	liquidity := fromDecimalString("2000000000000000000")
+	state := newTestPosition(60, -887220, 887220, liquidity, 3000)
+
+	amount := fromDecimalString("1000000000000000")
+	for _, zeroForOne := range []bool{true, false} {
+		for _, exactInput := range []bool{true, false} {
+			amountSpecified := new(big.Int).Set(amount)
+			if exactInput == false {
+				amountSpecified.Neg(amountSpecified)
+			}
+
+			result, err := state.simulateSwap(zeroForOne, amountSpecified, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// a swap that stays within the word reduces to a single computeSwapStep towards the next word boundary.
+			// Tick 0 is itself a word boundary, selling token0 first steps onto it without moving and continues from tick -1
+			tick := state.Tick
+			if zeroForOne {
+				tick--
+			}
+			tickNext, _, err := state.nextInitializedTickWithinOneWord(tick, zeroForOne)
+			if err != nil {
+				t.Fatal(err)
+			}
+			target, err := getSqrtRatioAtTick(tickNext)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sqrtQ, amountIn, amountOut, feeAmount, err := computeSwapStep(state.SqrtPriceX96, target, liquidity, amountSpecified, state.Fee)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			amountPaid, amountReceived := result.Amount0, new(big.Int).Neg(result.Amount1)
+			if zeroForOne == false {
+				amountPaid, amountReceived = result.Amount1, new(big.Int).Neg(result.Amount0)
+			}
+			if amountPaid.Cmp(new(big.Int).Add(amountIn, feeAmount)) != 0 || amountReceived.Cmp(amountOut) != 0 ||
+				result.FeeAmount.Cmp(feeAmount) != 0 || result.SqrtPriceX96.Cmp(sqrtQ) != 0 {
+				t.Errorf("zeroForOne %v exactInput %v: got in %v out %v fee %v price %v, want in %v out %v fee %v price %v",
+					zeroForOne, exactInput, amountPaid, amountReceived, result.FeeAmount, result.SqrtPriceX96,
+					new(big.Int).Add(amountIn, feeAmount), amountOut, feeAmount, sqrtQ)
+			}
+			if exactInput && amountPaid.Cmp(amount) != 0 {
+				t.Errorf("zeroForOne %v: exact input paid %v, want %v", zeroForOne, amountPaid, amount)
+			}
+			if exactInput == false && amountReceived.Cmp(amount) != 0 {
+				t.Errorf("zeroForOne %v: exact output received %v, want %v", zeroForOne, amountReceived, amount)
+			}
+			if result.InitializedTicksCrossed != 0 || result.Liquidity.Cmp(liquidity) != 0 {
+				t.Errorf("zeroForOne %v exactInput %v: crossed %d ticks, liquidity %v", zeroForOne, exactInput, result.InitializedTicksCrossed, result.Liquidity)
+			}
+		}
+	}
+
+	// the state itself is not modified
+	if state.SqrtPriceX96.Cmp(fromDecimalString("79228162514264337593543950336")) != 0 || state.Liquidity.Cmp(liquidity) != 0 {
+		t.Errorf("state modified: price %v liquidity %v", state.SqrtPriceX96, state.Liquidity)
+	}
+}
+
+func TestSimulateSwapCrossesOutOfLiquidity(t *testing.T) {
+	liquidity := fromDecimalString("1000000000000000000")
+	state := newTestPosition(60, -60, 60, liquidity, 3000)
+
+	sqrtPriceLower, err := getSqrtRatioAtTick(-60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount0, err := getAmount0Delta(sqrtPriceLower, state.SqrtPriceX96, liquidity, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount1, err := getAmount1Delta(sqrtPriceLower, state.SqrtPriceX96, liquidity, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feeAmount, err := mulDivRoundingUp(amount0, big.NewInt(3000), big.NewInt(1000000-3000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// selling far more than the position holds drains it and runs on to the price limit without liquidity
+	result, err := state.simulateSwap(true, fromDecimalString("1000000000000000000000"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Amount0.Cmp(new(big.Int).Add(amount0, feeAmount)) != 0 {
+		t.Errorf("amount0 %v, want %v", result.Amount0, new(big.Int).Add(amount0, feeAmount))
+	}
+	if result.Amount1.Cmp(new(big.Int).Neg(amount1)) != 0 {
+		t.Errorf("amount1 %v, want %v", result.Amount1, new(big.Int).Neg(amount1))
+	}
+	if result.Liquidity.Sign() != 0 || result.InitializedTicksCrossed != 1 {
+		t.Errorf("liquidity %v crossed %d, want 0 and 1", result.Liquidity, result.InitializedTicksCrossed)
+	}
+	if result.SqrtPriceX96.Cmp(new(big.Int).Add(MIN_SQRT_RATIO, big.NewInt(1))) != 0 {
+		t.Errorf("sqrtPriceX96 %v, want MIN_SQRT_RATIO + 1", result.SqrtPriceX96)
+	}
+
+	// with a limit at the lower tick the swap stops there, still before crossing it
+	result, err = state.simulateSwap(true, fromDecimalString("1000000000000000000000"), sqrtPriceLower)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SqrtPriceX96.Cmp(sqrtPriceLower) != 0 || result.Amount1.Cmp(new(big.Int).Neg(amount1)) != 0 {
+		t.Errorf("limited: price %v amount1 %v, want %v %v", result.SqrtPriceX96, result.Amount1, sqrtPriceLower, new(big.Int).Neg(amount1))
+	}
+	if result.InitializedTicksCrossed != 1 || result.Tick != -61 {
+		t.Errorf("limited: crossed %d tick %d, want 1 and -61", result.InitializedTicksCrossed, result.Tick)
+	}
+}
+
+func TestSimulateSwapErrors(t *testing.T) {
+	state := newTestPosition(60, -60, 60, fromDecimalString("1000000000000000000"), 3000)
+
+	if _, err := state.simulateSwap(true, big.NewInt(0), nil); err != errSwapAmountZero {
+		t.Errorf("zero amount: got %v, want %v", err, errSwapAmountZero)
+	}
+	if _, err := state.simulateSwap(true, big.NewInt(1), state.SqrtPriceX96); err != errSwapPriceLimit {
+		t.Errorf("limit at the current price: got %v, want %v", err, errSwapPriceLimit)
+	}
+	if _, err := state.simulateSwap(false, big.NewInt(1), MAX_SQRT_RATIO); err != errSwapPriceLimit {
+		t.Errorf("limit at MAX_SQRT_RATIO: got %v, want %v", err, errSwapPriceLimit)
+	}
+	if _, err := state.simulateSwap(true, big.NewInt(1), MIN_SQRT_RATIO); err != errSwapPriceLimit {
+		t.Errorf("limit at MIN_SQRT_RATIO: got %v, want %v", err, errSwapPriceLimit)
+	}
+}
+
+// TestSimulateSwapQuoterParity compares the simulation with QuoterV2 on a local chain. It needs DP_RAW_URL,
+// QUOTER_V2_CONTRACT_ADDRESS and TEST_V3_POOL (a pool with liquidity) and is skipped otherwise
+func TestSimulateSwapQuoterParity(t *testing.T) {
+	poolAddr := os.Getenv("TEST_V3_POOL")
+	quoterAddr := os.Getenv("QUOTER_V2_CONTRACT_ADDRESS")
+	rawURL = os.Getenv("DP_RAW_URL")
+	if len(poolAddr) == 0 || len(quoterAddr) == 0 || len(rawURL) == 0 {
+		t.Skip("set DP_RAW_URL, QUOTER_V2_CONTRACT_ADDRESS and TEST_V3_POOL to compare with QuoterV2 on a local chain")
+	}
+	quoterv2ContractAddress = common.HexToAddress(quoterAddr)
+
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := loadV3PoolState(client, common.HexToAddress(poolAddr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := &bind.CallOpts{BlockNumber: state.BlockNumber}
+
+	amounts := []string{"1000", "1000000000000000", "1000000000000000000", "100000000000000000000"}
+	for _, zeroForOne := range []bool{true, false} {
+		tokenIn, tokenOut := state.Token0, state.Token1
+		if zeroForOne == false {
+			tokenIn, tokenOut = state.Token1, state.Token0
+		}
+		for _, exactInput := range []bool{true, false} {
+			for _, value := range amounts {
+				amount := fromDecimalString(value)
+				amountSpecified := new(big.Int).Set(amount)
+				if exactInput == false {
+					amountSpecified.Neg(amountSpecified)
+				}
+
+				result, err := state.simulateSwap(zeroForOne, amountSpecified, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				quote, err := quoteV3(client, opts, []common.Address{tokenIn, tokenOut}, []int64{state.Fee}, amount, exactInput, nil)
+				if err != nil {
+					// QuoterV2 reverts on a partial exact output fill, the simulation reports it instead
+					t.Logf("zeroForOne %v exactInput %v amount %v: QuoterV2 error %v", zeroForOne, exactInput, value, err)
+					continue
+				}
+
+				amountIn, amountOut := result.Amount0, new(big.Int).Neg(result.Amount1)
+				if zeroForOne == false {
+					amountIn, amountOut = result.Amount1, new(big.Int).Neg(result.Amount0)
+				}
+				simulated := amountOut
+				if exactInput == false {
+					simulated = amountIn
+				}
+				if quote.Amount.Cmp(simulated) != 0 || quote.SqrtPriceX96AfterList[0].Cmp(result.SqrtPriceX96) != 0 {
+					t.Errorf("zeroForOne %v exactInput %v amount %v: quoted %v price %v, simulated %v price %v", zeroForOne, exactInput, value,
+						quote.Amount, quote.SqrtPriceX96AfterList[0], simulated, result.SqrtPriceX96)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"quantumswap-cli/contracts/corev2"
+	"quantumswap-cli/contracts/nonfungiblepositionmanager"
+	"quantumswap-cli/contracts/swaprouter"
+	"quantumswap-cli/contracts/v2swaprouter"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+	"github.com/quantumcoinproject/quantum-coin-go/crypto"
+	"github.com/quantumcoinproject/quantum-coin-go/ethclient"
+)
+
+// Verification of a deployed QuantumSwap stack, from the addresses configured in the environment (or DP_ADDRESS_BOOK).
+
+// EIP-1967 storage slots of TransparentUpgradeableProxy, keccak256("eip1967.proxy.admin") - 1 and keccak256("eip1967.proxy.implementation") - 1
+var EIP1967_ADMIN_SLOT = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+var EIP1967_IMPLEMENTATION_SLOT = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+// The v2 router is not deployed by deploy, but is verified when SWAP_ROUTER_V2_CONTRACT_ADDRESS is set
+const V2_SWAP_ROUTER_ARTIFACT = "UniswapV2Router02"
+const V2_SWAP_ROUTER_ADDRESS_ENV = "SWAP_ROUTER_V2_CONTRACT_ADDRESS"
+
+type deploymentCheck struct {
+	Name    string
+	Ok      bool
+	Skipped bool
+	Detail  string
+}
+
+func getConfiguredAddress(addressEnv string) (common.Address, bool) {
+	address := os.Getenv(addressEnv)
+	if common.IsHexAddress(address) == false {
+		return common.Address{}, false
+	}
+	return common.HexToAddress(address), true
+}
+
+// matchRuntimeCode compares deployed code with the runtime bytecode of solc. The immutable variables, and the libraries whose
+// address is not known, are filled in at deployment, so the bytes of those ranges are zeroed on both sides before hashing
+func matchRuntimeCode(code []byte, expected []byte, masks []solcReference) (common.Hash, common.Hash, bool) {
+	if len(code) != len(expected) {
+		return crypto.Keccak256Hash(expected), crypto.Keccak256Hash(code), false
+	}
+
+	maskedCode := make([]byte, len(code))
+	copy(maskedCode, code)
+	maskedExpected := make([]byte, len(expected))
+	copy(maskedExpected, expected)
+	for _, mask := range masks {
+		if mask.Start < 0 || mask.Start+mask.Length > len(code) {
+			return crypto.Keccak256Hash(expected), crypto.Keccak256Hash(code), false
+		}
+		for i := mask.Start; i < mask.Start+mask.Length; i++ {
+			maskedCode[i] = 0
+			maskedExpected[i] = 0
+		}
+	}
+
+	expectedHash := crypto.Keccak256Hash(maskedExpected)
+	actualHash := crypto.Keccak256Hash(maskedCode)
+	return expectedHash, actualHash, actualHash == expectedHash
+}
+
+// loadRuntimeCode returns the runtime bytecode of an artifact and the ranges of it that are only known once deployed.
+// ARTIFACTS_DIR/<Artifact>.json has the ranges of the immutable variables and of the libraries; ARTIFACTS_DIR/<Artifact>.bin-runtime
+// has neither, it is compared as is after linking the NFTDescriptor library
+func loadRuntimeCode(artifactsDir string, artifact string) ([]byte, []solcReference, error) {
+	contract, err := loadSolcContract(artifactsDir, artifact)
+	if err == nil {
+		return getRuntimeCode(contract)
+	}
+	if os.IsNotExist(err) == false {
+		return nil, nil, err
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(artifactsDir, artifact+".bin-runtime"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bin := strings.TrimPrefix(strings.TrimSpace(string(data)), "0x")
+	if strings.Contains(bin, "__") {
+		libraryAddress, ok := getConfiguredAddress(nftDescriptorLibraryContract.AddressEnv)
+		if ok == false {
+			return nil, nil, fmt.Errorf("%s links the NFTDescriptor library but %s is not set", artifact, nftDescriptorLibraryContract.AddressEnv)
+		}
+		bin = linkLibrary(bin, NFT_DESCRIPTOR_LIBRARY_NAME, libraryAddress)
+	}
+
+	code, err := hex.DecodeString(bin)
+	return code, nil, err
+}
+
+// getRuntimeCode links the NFTDescriptor library into the deployed bytecode of contract when its address is configured, and
+// returns the ranges of the immutable variables and of the other libraries to mask
+func getRuntimeCode(contract *solcContract) ([]byte, []solcReference, error) {
+	runtime := contract.Evm.DeployedBytecode
+	bin := strings.TrimPrefix(runtime.Object, "0x")
+
+	masks := make([]solcReference, 0)
+	for _, references := range runtime.ImmutableReferences {
+		masks = append(masks, references...)
+	}
+	for sourceFile, libraries := range runtime.LinkReferences {
+		for libraryName, references := range libraries {
+			libraryAddress, ok := getConfiguredAddress(nftDescriptorLibraryContract.AddressEnv)
+			linked := ok && sourceFile+":"+libraryName == NFT_DESCRIPTOR_LIBRARY_NAME
+			for _, reference := range references {
+				value := make([]byte, reference.Length)
+				if linked {
+					value = libraryAddress.Bytes()
+				} else {
+					masks = append(masks, reference)
+				}
+				var err error
+				bin, err = fillBytecode(bin, reference, value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("library %s: %w", libraryName, err)
+				}
+			}
+		}
+	}
+
+	code, err := hex.DecodeString(bin)
+	return code, masks, err
+}
+
+func checkCode(client *ethclient.Client, name string, artifact string, address common.Address, artifactsDir string) deploymentCheck {
+	check := deploymentCheck{Name: name + " code"}
+
+	code, err := client.CodeAt(context.Background(), address, nil)
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	if len(code) == 0 {
+		check.Detail = fmt.Sprintf("no code at %s", address)
+		return check
+	}
+
+	if len(artifactsDir) == 0 {
+		check.Skipped = true
+		check.Detail = fmt.Sprintf("code at %s, not compared without ARTIFACTS_DIR", address)
+		return check
+	}
+
+	expected, masks, err := loadRuntimeCode(artifactsDir, artifact)
+	if err != nil {
+		if os.IsNotExist(err) {
+			check.Skipped = true
+			check.Detail = fmt.Sprintf("neither %s.json nor %s.bin-runtime found", artifact, artifact)
+			return check
+		}
+		check.Detail = err.Error()
+		return check
+	}
+
+	expectedHash, actualHash, ok := matchRuntimeCode(code, expected, masks)
+	check.Ok = ok
+	check.Detail = fmt.Sprintf("expected code hash %s, deployed %s", expectedHash, actualHash)
+	if ok == false && masks == nil {
+		check.Detail += fmt.Sprintf(", immutable variables can only be masked with %s.json", artifact)
+	}
+	return check
+}
+
+func checkAddress(name string, actual common.Address, err error, expectedEnv string) deploymentCheck {
+	check := deploymentCheck{Name: name}
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+
+	expected, ok := getConfiguredAddress(expectedEnv)
+	if ok == false {
+		check.Skipped = true
+		check.Detail = fmt.Sprintf("%s, %s is not set", actual, expectedEnv)
+		return check
+	}
+
+	check.Ok = actual.IsEqualTo(expected)
+	check.Detail = fmt.Sprintf("%s, expected %s %s", actual, expectedEnv, expected)
+	return check
+}
+
+func verifyDeployment(artifactsDir string) ([]deploymentCheck, error) {
+	client, err := ethclient.Dial(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]deploymentCheck, 0)
+
+	for _, settings := range getDeploymentOrder() {
+		address, ok := getConfiguredAddress(settings.AddressEnv)
+		if ok == false {
+			checks = append(checks, deploymentCheck{Name: settings.ContractName + " code", Skipped: true, Detail: settings.AddressEnv + " is not set"})
+			continue
+		}
+		checks = append(checks, checkCode(client, settings.ContractName, settings.Artifact, address, artifactsDir))
+	}
+
+	v2RouterAddress, ok := getConfiguredAddress(V2_SWAP_ROUTER_ADDRESS_ENV)
+	if ok {
+		checks = append(checks, checkCode(client, "V2SwapRouterContract", V2_SWAP_ROUTER_ARTIFACT, v2RouterAddress, artifactsDir))
+
+		router, err := v2swaprouter.NewV2swaprouter(v2RouterAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		factory, err := router.Factory(nil)
+		checks = append(checks, checkAddress("V2SwapRouterContract Factory()", factory, err, v2CoreContract.AddressEnv))
+		weth, err := router.WETH(nil)
+		checks = append(checks, checkAddress("V2SwapRouterContract WETH()", weth, err, wqContract.AddressEnv))
+	}
+
+	swapRouterAddress, ok := getConfiguredAddress(v3SwapRouterContract.AddressEnv)
+	if ok {
+		router, err := swaprouter.NewSwaprouter(swapRouterAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		factory, err := router.Factory(nil)
+		checks = append(checks, checkAddress("V3SwapRouterContract factory()", factory, err, v3CoreContract.AddressEnv))
+		factoryV2, err := router.FactoryV2(nil)
+		checks = append(checks, checkAddress("V3SwapRouterContract factoryV2()", factoryV2, err, v2CoreContract.AddressEnv))
+		positionManager, err := router.PositionManager(nil)
+		checks = append(checks, checkAddress("V3SwapRouterContract positionManager()", positionManager, err, nonfungiblePositionManagerContract.AddressEnv))
+		weth9, err := router.WETH9(nil)
+		checks = append(checks, checkAddress("V3SwapRouterContract WETH9()", weth9, err, wqContract.AddressEnv))
+	}
+
+	positionManagerAddress, ok := getConfiguredAddress(nonfungiblePositionManagerContract.AddressEnv)
+	if ok {
+		positionManager, err := nonfungiblepositionmanager.NewNonfungiblepositionmanager(positionManagerAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		factory, err := positionManager.Factory(nil)
+		checks = append(checks, checkAddress("NonfungiblePositionManagerContract factory()", factory, err, v3CoreContract.AddressEnv))
+		weth9, err := positionManager.WETH9(nil)
+		checks = append(checks, checkAddress("NonfungiblePositionManagerContract WETH9()", weth9, err, wqContract.AddressEnv))
+	}
+
+	v2FactoryAddress, ok := getConfiguredAddress(v2CoreContract.AddressEnv)
+	if ok {
+		check := deploymentCheck{Name: "V2CoreContract INIT_CODE_HASH()"}
+		factory, err := corev2.NewCorev2(v2FactoryAddress, client)
+		if err != nil {
+			return nil, err
+		}
+		initCodeHash, err := factory.INITCODEHASH(nil)
+		if err != nil {
+			check.Detail = err.Error()
+		} else {
+			// pair addresses are computed off-chain from the hash of the pair creation code
+			pairCodeHash := getV2PairInitCodeHash()
+			check.Ok = common.Hash(initCodeHash) == pairCodeHash
+			check.Detail = fmt.Sprintf("%s, pair bytecode hash %s", common.Hash(initCodeHash), pairCodeHash)
+		}
+		checks = append(checks, check)
+	}
+
+	proxyAddress, ok := getConfiguredAddress(transparentProxyContract.AddressEnv)
+	if ok {
+		admin, err := client.StorageAt(context.Background(), proxyAddress, EIP1967_ADMIN_SLOT, nil)
+		checks = append(checks, checkAddress("TransparentProxyContract admin", common.BytesToAddress(admin), err, proxyAdminContract.AddressEnv))
+		implementation, err := client.StorageAt(context.Background(), proxyAddress, EIP1967_IMPLEMENTATION_SLOT, nil)
+		checks = append(checks, checkAddress("TransparentProxyContract implementation", common.BytesToAddress(implementation), err, nftPositionDescriptorContract.AddressEnv))
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/quantumcoinproject/quantum-coin-go/common"
+)
+
+func TestMatchRuntimeCode(t *testing.T) {
+	// 0x7f followed by an immutable of 4 bytes, then a byte that solc left as zero
+	expected := []byte{0x7f, 0, 0, 0, 0, 0x60, 0}
+	masks := []solcReference{{Start: 1, Length: 4}}
+
+	tests := []struct {
+		name  string
+		code  []byte
+		masks []solcReference
+		match bool
+	}{
+		{"identical", []byte{0x7f, 0, 0, 0, 0, 0x60, 0}, masks, true},
+		{"immutable filled in", []byte{0x7f, 1, 2, 3, 4, 0x60, 0}, masks, true},
+		{"zero byte outside the immutables differs", []byte{0x7f, 1, 2, 3, 4, 0x60, 1}, masks, false},
+		{"immutable without its range", []byte{0x7f, 1, 2, 3, 4, 0x60, 0}, nil, false},
+		{"different length", []byte{0x7f, 0, 0, 0, 0, 0x60}, masks, false},
+		{"range out of the code", []byte{0x7f, 0, 0, 0, 0, 0x60, 0}, []solcReference{{Start: 5, Length: 4}}, false},
+	}
+
+	for _, test := range tests {
+		_, _, match := matchRuntimeCode(test.code, expected, test.masks)
+		if match != test.match {
+			t.Errorf("%s: match %v, want %v", test.name, match, test.match)
+		}
+	}
+}
+
+func TestGetRuntimeCode(t *testing.T) {
+	libraryAddress := common.HexToAddress("0x" + strings.Repeat("ab", common.AddressLength))
+	placeholder := strings.Repeat("5f", common.AddressLength)
+
+	contract := &solcContract{}
+	contract.Evm.DeployedBytecode = solcBytecode{
+		Object: "0x60" + placeholder + "61" + placeholder + "62" + strings.Repeat("00", 4),
+		LinkReferences: map[string]map[string][]solcReference{
+			"contracts/libraries/NFTDescriptor.sol": {"NFTDescriptor": {{Start: 1, Length: common.AddressLength}}},
+			"contracts/libraries/Other.sol":         {"Other": {{Start: 2 + common.AddressLength, Length: common.AddressLength}}},
+		},
+		ImmutableReferences: map[string][]solcReference{"12": {{Start: 3 + 2*common.AddressLength, Length: 4}}},
+	}
+
+	os.Setenv(nftDescriptorLibraryContract.AddressEnv, libraryAddress.Hex())
+	defer os.Unsetenv(nftDescriptorLibraryContract.AddressEnv)
+
+	code, masks, err := getRuntimeCode(contract)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := hex.DecodeString("60" + hex.EncodeToString(libraryAddress.Bytes()) + "61" + strings.Repeat("00", common.AddressLength) + "62" + strings.Repeat("00", 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(code, want) == false {
+		t.Errorf("code %x, want %x", code, want)
+	}
+
+	// the configured library is compared, the other library and the immutable are masked
+	if len(masks) != 2 {
+		t.Fatalf("masks %v, want the Other library and the immutable", masks)
+	}
+	for _, mask := range masks {
+		if mask.Start == 1 {
+			t.Errorf("the configured NFTDescriptor library is masked")
+		}
+	}
+}